@@ -3,10 +3,13 @@ package compare
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/CrowdStrike/gql/pkg/compare"
+	"github.com/CrowdStrike/gql/pkg/config"
 	"github.com/CrowdStrike/gql/utils"
 )
 
@@ -15,6 +18,30 @@ var (
 	newSchemaPath      string
 	onlyBreakingChange bool
 	excludeFilePath    bool
+	outputFormat       string
+	configPath         string
+	failOn             []string
+	baseRef            string
+	headRef            string
+	oldEndpoint        string
+	headers            []string
+	operationPaths     []string
+	federation         string
+	mergeExtensions    bool
+	renameThreshold    float64
+	deprecationPolicy  bool
+	schemaVersion      string
+	acceptFile         string
+	snippets           bool
+	linkTemplate       string
+	oldLinkTemplate    string
+)
+
+const (
+	textFormat     = "text"
+	jsonFormat     = "json"
+	markdownFormat = "markdown"
+	junitFormat    = "junit"
 )
 
 //NewCompareCmd creates new compare command
@@ -24,22 +51,43 @@ func NewCompareCmd() *cobra.Command {
 		Short: "compare two graphql schemas",
 		Long:  "compare two graphql schemas",
 		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := config.Resolve(configPath)
+			if err != nil {
+				fmt.Printf("failed to load config file, error:%v", err)
+				os.Exit(1)
+			}
+			policy, err := resolveFlags(cmd, cfg)
+			if err != nil {
+				fmt.Printf("%v\n", err)
+				os.Exit(1)
+			}
+			failOnCriticalities, err := compare.ParseFailOn(failOn)
+			if err != nil {
+				fmt.Printf("failed to parse fail-on criticalities, error:%v\n", err)
+				os.Exit(1)
+			}
+
 			if len(oldSchemaPath) == 0 || len(newSchemaPath) == 0 {
 				fmt.Print("compare expects two version of schemas in the arguments\n")
 				os.Exit(1)
 			}
-			if oldSchemaPath == newSchemaPath {
+			reporter, err := newReporter(outputFormat, failOnCriticalities)
+			if err != nil {
+				fmt.Printf("%v\n", err)
+				os.Exit(1)
+			}
+			if oldSchemaPath == newSchemaPath && baseRef == headRef {
 				fmt.Printf("Both old '%s' and new '%s' schema path are same\n", oldSchemaPath, newSchemaPath)
 				os.Exit(1)
 			}
 
-			schemaOldContents, err := utils.ReadFiles(oldSchemaPath)
+			schemaOldContents, err := readSchemaFiles(baseRef, oldSchemaPath)
 			if err != nil {
 				fmt.Printf("failed to read schema files on filepath:%s, error:%v", oldSchemaPath, err)
 				os.Exit(1)
 			}
 
-			schemaNewContents, err := utils.ReadFiles(newSchemaPath)
+			schemaNewContents, err := readSchemaFiles(headRef, newSchemaPath)
 			if err != nil {
 				fmt.Printf("failed to read schema files on filepath:%s, error:%v", newSchemaPath, err)
 				os.Exit(1)
@@ -56,34 +104,224 @@ func NewCompareCmd() *cobra.Command {
 				fmt.Printf("Error parsing schema content on path=%s, error:%v", newSchemaPath, parseErr)
 				os.Exit(1)
 			}
-			exitStatus := 0
-			changes := compare.FindChangesInSchemas(schemaOld, schemaNew)
-			if len(changes) == 0 {
-				fmt.Println("No changes found on schema compare!")
-			} else {
-				changeCriticalityMap := compare.GroupChanges(changes)
-				errorCount := 0
-				//print changes
-				if onlyBreakingChange {
-					errorCount = compare.ReportBreakingChanges(changeCriticalityMap[compare.Breaking], !excludeFilePath)
-				} else {
-					errorCount = compare.ReportBreakingChanges(changeCriticalityMap[compare.Breaking], !excludeFilePath)
-					compare.ReportDangerousChanges(changeCriticalityMap[compare.Dangerous], !excludeFilePath)
-					compare.ReportNonBreakingChanges(changeCriticalityMap[compare.NonBreaking], !excludeFilePath)
+			compareOptions := []compare.Option{compare.WithMergedExtensions(mergeExtensions)}
+			if renameThreshold < 0 || renameThreshold > 1 {
+				fmt.Printf("invalid rename similarity threshold %v, expected a number between 0 and 1\n", renameThreshold)
+				os.Exit(1)
+			}
+			if renameThreshold > 0 {
+				compareOptions = append(compareOptions, compare.WithRenameDetection(renameThreshold))
+			}
+			if len(federation) != 0 {
+				federationVersion, err := compare.ParseFederationVersion(federation)
+				if err != nil {
+					fmt.Printf("failed to parse federation version, error:%v\n", err)
+					os.Exit(1)
+				}
+				compareOptions = append(compareOptions, compare.WithFederation(federationVersion))
+			}
+			if len(operationPaths) != 0 {
+				operations, err := readOperations(operationPaths)
+				if err != nil {
+					fmt.Printf("failed to read client operations, error:%v", err)
+					os.Exit(1)
+				}
+				compareOptions = append(compareOptions, compare.WithOperations(operations))
+			}
+			if deprecationPolicy {
+				compareOptions = append(compareOptions, compare.WithDeprecationPolicy(policy))
+			}
+			if len(cfg.Compare.Overrides) != 0 {
+				overrides, err := criticalityOverrides(cfg.Compare.Overrides)
+				if err != nil {
+					fmt.Printf("failed to parse criticality overrides, error:%v\n", err)
+					os.Exit(1)
+				}
+				compareOptions = append(compareOptions, compare.WithCriticalityOverrides(overrides))
+			}
+			changes := compare.FindChangesInSchemas(schemaOld, schemaNew, compareOptions...)
+			var staleAccepted []*compare.StaleAcceptedChange
+			if len(acceptFile) != 0 {
+				content, err := os.ReadFile(acceptFile)
+				if err != nil {
+					fmt.Printf("failed to read accepted changes on filepath:%s, error:%v", acceptFile, err)
+					os.Exit(1)
 				}
-				if errorCount == 0 {
-					fmt.Println("No breaking changes found 🎉")
-				} else {
-					fmt.Printf("\n❌ Breaking changes in schema: %d\n", errorCount)
-					exitStatus |= 1
+				accepted, err := compare.ParseAcceptedChanges(content)
+				if err != nil {
+					fmt.Printf("failed to parse accepted changes on filepath:%s, error:%v", acceptFile, err)
+					os.Exit(1)
 				}
+				staleAccepted = compare.AcceptChanges(changes, accepted)
+			}
+			exitStatus := compare.ExitStatus(changes, failOnCriticalities)
+			// the text reporter filters the breaking changes itself, to tell when there are only other changes
+			if onlyBreakingChange && outputFormat != textFormat {
+				changes = compare.GroupChanges(changes)[compare.Breaking]
+			}
+			if err := compare.WriteReport(reporter, changes, staleAccepted); err != nil {
+				fmt.Printf("failed to write %s report, error:%v", outputFormat, err)
+				os.Exit(1)
 			}
 			os.Exit(exitStatus)
 		},
 	}
-	compareCmd.PersistentFlags().StringVarP(&oldSchemaPath, "oldversion", "o", "", "Path to your older version of GraphQL schema")
-	compareCmd.PersistentFlags().StringVarP(&newSchemaPath, "newversion", "n", "", "Path to your new version of GraphQL schema")
+	compareCmd.PersistentFlags().StringVarP(&oldSchemaPath, "oldversion", "o", "", "Path to your older version of GraphQL schema, or URL of the GraphQL endpoint serving it")
+	compareCmd.PersistentFlags().StringVarP(&newSchemaPath, "newversion", "n", "", "Path to your new version of GraphQL schema, or URL of the GraphQL endpoint serving it")
+	compareCmd.PersistentFlags().StringVar(&oldEndpoint, "old-endpoint", "", "URL of the GraphQL endpoint serving the older version of GraphQL schema, read with the introspection query")
+	compareCmd.PersistentFlags().StringArrayVarP(&headers, "header", "H", []string{}, "Header sent to GraphQL endpoints e.g.(-H 'Authorization: Bearer token'), can be passed multiple times")
 	compareCmd.PersistentFlags().BoolVarP(&onlyBreakingChange, "breaking-change-only", "b", false, "Get breaking change only")
 	compareCmd.PersistentFlags().BoolVarP(&excludeFilePath, "exclude-print-filepath", "e", false, "Exclude printing schema filepath positions")
+	compareCmd.PersistentFlags().BoolVar(&snippets, "snippets", false, "Print the source lines of the old and new schema around every change")
+	compareCmd.PersistentFlags().StringVar(&outputFormat, "format", textFormat, "Output format, one of: text, json, markdown, junit")
+	compareCmd.PersistentFlags().StringVar(&linkTemplate, "link-template", "", "Link of the new schema positions in the markdown output, {file} and {line} are replaced with the position e.g.(--link-template 'https://github.com/org/repo/blob/main/{file}#L{line}'); a link without placeholders is used as the base url of the files")
+	compareCmd.PersistentFlags().StringVar(&oldLinkTemplate, "old-link-template", "", "Link of the old schema positions in the markdown output, the --link-template is used when it is not passed")
+	compareCmd.PersistentFlags().StringVar(&configPath, "config", "", fmt.Sprintf("Path to the config file, by default the first of %s found walking up from the working directory", strings.Join(config.FileNames, ", ")))
+	compareCmd.PersistentFlags().StringVar(&baseRef, "base-ref", "", "Git revision to read the older version of GraphQL schema from e.g.(--base-ref origin/main), the newer version path is used when the older one is not passed")
+	compareCmd.PersistentFlags().StringVar(&headRef, "head-ref", "", "Git revision to read the newer version of GraphQL schema from instead of the working directory")
+	compareCmd.PersistentFlags().StringArrayVar(&operationPaths, "operations", []string{}, "Glob of the client operations, or persisted query manifest json, breaking changes are checked against e.g.(--operations 'clients/**/*.graphql'); breaking changes no operation uses are reported as SafeUnused")
+	compareCmd.PersistentFlags().StringVar(&federation, "federation", "", "Apollo Federation version of the subgraph schemas, one of: v1, v2; entity keys and federation directives are compared with the federation rules")
+	compareCmd.PersistentFlags().BoolVar(&mergeExtensions, "merge-extensions", true, "Fold every type and its extensions into one type before comparing, so moving fields between a type and its extensions isn't reported; --merge-extensions=false compares them separately")
+	compareCmd.PersistentFlags().Float64Var(&renameThreshold, "detect-renames", 0, fmt.Sprintf("Report removed and added types, fields, arguments and enum values as renamed when their similarity is at least the threshold e.g.(--detect-renames=0.9), %v when no threshold is passed", compare.DefaultRenameThreshold))
+	compareCmd.PersistentFlags().Lookup("detect-renames").NoOptDefVal = fmt.Sprint(compare.DefaultRenameThreshold)
+	compareCmd.PersistentFlags().BoolVar(&deprecationPolicy, "deprecation-policy", false, fmt.Sprintf("Check removed fields, input fields, arguments and enum values against their deprecation: removals after the removal date e.g.(@deprecated(%s: \"2024-01-01\")) or version are Dangerous, removals which skipped deprecation or came too early are Breaking", compare.DefaultRemovalArgument))
+	compareCmd.PersistentFlags().StringVar(&schemaVersion, "schema-version", "", "Version of the new schema removal versions in deprecation reasons are compared with e.g.(--schema-version 3.0 allows removing fields deprecated with 'Removed in v3.0')")
+	compareCmd.PersistentFlags().StringVar(&acceptFile, "accept-file", "", "Path to the yaml file listing the accepted changes by changeType and path, with an optional expires date and justification e.g.(--accept-file .gql-accepted.yaml); accepted changes don't fail the compare and stale entries are reported")
+	compareCmd.PersistentFlags().StringSliceVar(&failOn, "fail-on", []string{compare.Breaking.String()}, "Criticalities of changes which fail the compare e.g.(--fail-on Breaking,Dangerous)")
 	return compareCmd
 }
+
+// newReporter creates the reporter of the output format, junit reports the changes of the fail-on criticalities as failures
+// and markdown links the positions with the link templates
+func newReporter(format string, failOn []compare.Criticality) (compare.Reporter, error) {
+	switch format {
+	case textFormat:
+		reporter := compare.NewTextReporter(os.Stdout)
+		reporter.WithFilepath = !excludeFilePath
+		reporter.Snippets = snippets
+		reporter.BreakingOnly = onlyBreakingChange
+		return reporter, nil
+	case jsonFormat:
+		return compare.NewJSONReporter(os.Stdout), nil
+	case markdownFormat:
+		reporter := compare.NewMarkdownReporter(os.Stdout)
+		reporter.LinkTemplate = linkTemplate
+		reporter.OldLinkTemplate = oldLinkTemplate
+		return reporter, nil
+	case junitFormat:
+		reporter := compare.NewJUnitReporter(os.Stdout)
+		reporter.FailOn = failOn
+		return reporter, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format '%s', expected one of: %s, %s, %s, %s", format, textFormat, jsonFormat, markdownFormat, junitFormat)
+	}
+}
+
+// resolveFlags applies the config file to the flags which weren't passed on the command line, checks the sources of the
+// older version of schema can be combined and returns the deprecation policy
+func resolveFlags(cmd *cobra.Command, cfg *config.Config) (compare.DeprecationPolicy, error) {
+	if len(oldEndpoint) != 0 && (cmd.Flags().Changed("oldversion") || len(baseRef) != 0) {
+		return compare.DeprecationPolicy{}, fmt.Errorf("--old-endpoint can't be combined with --oldversion or --base-ref, pass only one source of the older version of schema")
+	}
+	// flags passed on the command line override the config file
+	if !cmd.Flags().Changed("oldversion") && len(cfg.Compare.Baseline) != 0 {
+		oldSchemaPath = cfg.ResolvePath(cfg.Compare.Baseline)
+	}
+	if !cmd.Flags().Changed("newversion") && len(cfg.Compare.Schema) != 0 {
+		newSchemaPath = cfg.ResolvePath(cfg.Compare.Schema)
+	}
+	if len(baseRef) != 0 && !cmd.Flags().Changed("oldversion") {
+		// the baseline is the same schema glob read from the base revision
+		oldSchemaPath = newSchemaPath
+	}
+	if len(oldEndpoint) != 0 {
+		oldSchemaPath = oldEndpoint
+	}
+	if !cmd.Flags().Changed("operations") && len(cfg.Compare.Operations) != 0 {
+		operationPaths = make([]string, 0, len(cfg.Compare.Operations))
+		for _, operationPath := range cfg.Compare.Operations {
+			operationPaths = append(operationPaths, cfg.ResolvePath(operationPath))
+		}
+	}
+	if !cmd.Flags().Changed("federation") && len(cfg.Compare.Federation) != 0 {
+		federation = cfg.Compare.Federation
+	}
+	if !cmd.Flags().Changed("accept-file") && len(cfg.Compare.AcceptFile) != 0 {
+		acceptFile = cfg.ResolvePath(cfg.Compare.AcceptFile)
+	}
+	if !cmd.Flags().Changed("link-template") && len(cfg.Compare.LinkTemplate) != 0 {
+		linkTemplate = cfg.Compare.LinkTemplate
+	}
+	if !cmd.Flags().Changed("old-link-template") && len(cfg.Compare.OldLinkTemplate) != 0 {
+		oldLinkTemplate = cfg.Compare.OldLinkTemplate
+	}
+	policy := compare.DeprecationPolicy{}
+	if cfg.Compare.DeprecationPolicy != nil {
+		policy.Directive = cfg.Compare.DeprecationPolicy.Directive
+		policy.Argument = cfg.Compare.DeprecationPolicy.Argument
+		policy.CurrentVersion = cfg.Compare.DeprecationPolicy.CurrentVersion
+		policy.MinimumWindow = time.Duration(cfg.Compare.DeprecationPolicy.MinimumWindowDays) * 24 * time.Hour
+		if !cmd.Flags().Changed("deprecation-policy") {
+			deprecationPolicy = true
+		}
+	}
+	if cmd.Flags().Changed("schema-version") {
+		policy.CurrentVersion = schemaVersion
+	}
+	if !cmd.Flags().Changed("fail-on") && len(cfg.Compare.FailOn) != 0 {
+		failOn = cfg.Compare.FailOn
+	}
+	return policy, nil
+}
+
+// readSchemaFiles reads the schema files from the working directory, or from the git revision when one is given.
+// Schema paths which are URLs are read from the GraphQL endpoint with the introspection query.
+func readSchemaFiles(ref string, schemaPath string) (map[string][]byte, error) {
+	if utils.IsEndpoint(schemaPath) {
+		return utils.ReadEndpoint(schemaPath, headers)
+	}
+	if len(ref) == 0 {
+		return utils.ReadFiles(schemaPath)
+	}
+	return utils.ReadFilesFromGitRef(ref, schemaPath)
+}
+
+// readOperations reads and parses the client operations from all the globs, '**' in a glob matches any number of directories
+func readOperations(operationPaths []string) (*compare.Operations, error) {
+	operationFileContents := map[string][]byte{}
+	for _, operationPath := range operationPaths {
+		operationFiles, err := utils.Glob(operationPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operations glob:%s, error:%v", operationPath, err)
+		}
+		if len(operationFiles) == 0 {
+			return nil, fmt.Errorf("matching file does not exist at path:%s", operationPath)
+		}
+		for _, fileName := range operationFiles {
+			content, err := os.ReadFile(fileName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read file:%s on path:%s, error:%v", fileName, operationPath, err)
+			}
+			operationFileContents[fileName] = content
+		}
+	}
+	return compare.ParseOperations(operationFileContents)
+}
+
+// criticalityOverrides converts the criticality overrides of the config file
+func criticalityOverrides(configOverrides []config.CriticalityOverride) ([]compare.CriticalityOverride, error) {
+	overrides := make([]compare.CriticalityOverride, 0, len(configOverrides))
+	for _, o := range configOverrides {
+		criticality, err := compare.ParseCriticality(o.Criticality)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, compare.CriticalityOverride{
+			ChangeType:  compare.ChangeType(o.ChangeType),
+			Path:        o.Path,
+			Directive:   o.Directive,
+			Criticality: criticality,
+		})
+	}
+	return overrides, compare.ValidateCriticalityOverrides(overrides)
+}
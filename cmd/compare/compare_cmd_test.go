@@ -0,0 +1,128 @@
+package compare
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CrowdStrike/gql/pkg/compare"
+	"github.com/CrowdStrike/gql/pkg/config"
+)
+
+func TestResolveFlags(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		cfg           config.Config
+		wantOld       string
+		wantNew       string
+		wantFailOn    []string
+		wantPolicy    compare.DeprecationPolicy
+		wantDeprecate bool
+		wantErr       bool
+	}{
+		{
+			name:       "flags without config",
+			args:       []string{"-o", "old.graphql", "-n", "new.graphql"},
+			wantOld:    "old.graphql",
+			wantNew:    "new.graphql",
+			wantFailOn: []string{"Breaking"},
+		},
+		{
+			name:       "config fills flags which weren't passed",
+			args:       []string{},
+			cfg:        config.Config{Compare: config.CompareConfig{Baseline: "old/*.graphql", Schema: "new/*.graphql", FailOn: []string{"Breaking", "Dangerous"}}},
+			wantOld:    "old/*.graphql",
+			wantNew:    "new/*.graphql",
+			wantFailOn: []string{"Breaking", "Dangerous"},
+		},
+		{
+			name:       "flags override config",
+			args:       []string{"-o", "old.graphql", "--fail-on", "Dangerous"},
+			cfg:        config.Config{Compare: config.CompareConfig{Baseline: "old/*.graphql", Schema: "new/*.graphql", FailOn: []string{"Breaking"}}},
+			wantOld:    "old.graphql",
+			wantNew:    "new/*.graphql",
+			wantFailOn: []string{"Dangerous"},
+		},
+		{
+			name:       "endpoint baseline from config",
+			args:       []string{"-n", "new.graphql"},
+			cfg:        config.Config{Compare: config.CompareConfig{Baseline: "https://library.example.com/graphql"}},
+			wantOld:    "https://library.example.com/graphql",
+			wantNew:    "new.graphql",
+			wantFailOn: []string{"Breaking"},
+		},
+		{
+			name:       "base ref reads the new schema glob from the base revision",
+			args:       []string{"--base-ref", "origin/main", "-n", "schema/*.graphql"},
+			cfg:        config.Config{Compare: config.CompareConfig{Baseline: "old/*.graphql"}},
+			wantOld:    "schema/*.graphql",
+			wantNew:    "schema/*.graphql",
+			wantFailOn: []string{"Breaking"},
+		},
+		{
+			name:       "old endpoint overrides the config baseline",
+			args:       []string{"--old-endpoint", "https://library.example.com/graphql", "-n", "new.graphql"},
+			cfg:        config.Config{Compare: config.CompareConfig{Baseline: "old/*.graphql"}},
+			wantOld:    "https://library.example.com/graphql",
+			wantNew:    "new.graphql",
+			wantFailOn: []string{"Breaking"},
+		},
+		{
+			name:    "old endpoint with oldversion",
+			args:    []string{"--old-endpoint", "https://library.example.com/graphql", "-o", "old.graphql", "-n", "new.graphql"},
+			wantErr: true,
+		},
+		{
+			name:    "old endpoint with base ref",
+			args:    []string{"--old-endpoint", "https://library.example.com/graphql", "--base-ref", "origin/main", "-n", "new.graphql"},
+			wantErr: true,
+		},
+		{
+			name: "deprecation policy from config",
+			args: []string{"-o", "old.graphql", "-n", "new.graphql", "--schema-version", "3.1"},
+			cfg: config.Config{Compare: config.CompareConfig{DeprecationPolicy: &config.DeprecationPolicyConfig{
+				Directive: "sunset", Argument: "date", CurrentVersion: "3.0", MinimumWindowDays: 90,
+			}}},
+			wantOld:       "old.graphql",
+			wantNew:       "new.graphql",
+			wantFailOn:    []string{"Breaking"},
+			wantPolicy:    compare.DeprecationPolicy{Directive: "sunset", Argument: "date", CurrentVersion: "3.1", MinimumWindow: 90 * 24 * time.Hour},
+			wantDeprecate: true,
+		},
+		{
+			name:       "deprecation policy turned off on the command line",
+			args:       []string{"-o", "old.graphql", "-n", "new.graphql", "--deprecation-policy=false"},
+			cfg:        config.Config{Compare: config.CompareConfig{DeprecationPolicy: &config.DeprecationPolicyConfig{}}},
+			wantOld:    "old.graphql",
+			wantNew:    "new.graphql",
+			wantFailOn: []string{"Breaking"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// creating the command resets the flags to their defaults
+			cmd := NewCompareCmd()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			policy, err := resolveFlags(cmd, &tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if oldSchemaPath != tt.wantOld || newSchemaPath != tt.wantNew {
+				t.Errorf("resolveFlags() old = %v, new = %v, want old = %v, new = %v", oldSchemaPath, newSchemaPath, tt.wantOld, tt.wantNew)
+			}
+			if !reflect.DeepEqual(failOn, tt.wantFailOn) {
+				t.Errorf("resolveFlags() failOn = %v, want %v", failOn, tt.wantFailOn)
+			}
+			if !reflect.DeepEqual(policy, tt.wantPolicy) || deprecationPolicy != tt.wantDeprecate {
+				t.Errorf("resolveFlags() policy = %+v, enabled %v, want %+v, enabled %v", policy, deprecationPolicy, tt.wantPolicy, tt.wantDeprecate)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package linter
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const diffContextLines = 3
+
+// diffOp is a line of the diff, kind is one of ' ', '-' or '+'
+type diffOp struct {
+	kind rune
+	line string
+}
+
+// UnifiedDiff returns the unified diff between the old and new content of a file, empty when they are the same
+func UnifiedDiff(fileName string, oldContent string, newContent string) string {
+	if oldContent == newContent {
+		return ""
+	}
+	ops := diffLines(splitLines(oldContent), splitLines(newContent))
+
+	var b strings.Builder
+	path := strings.TrimPrefix(filepath.ToSlash(fileName), "/")
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", path, path)
+	for start := 0; start < len(ops); {
+		// find the next change and the hunk around it
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		hunkStart := first - diffContextLines
+		if hunkStart < start {
+			hunkStart = start
+		}
+		hunkEnd := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				hunkEnd = i + 1
+				continue
+			}
+			if i-hunkEnd >= 2*diffContextLines {
+				break
+			}
+		}
+		hunkEnd += diffContextLines
+		if hunkEnd > len(ops) {
+			hunkEnd = len(ops)
+		}
+
+		oldLine, newLine := 1, 1
+		for _, op := range ops[:hunkStart] {
+			if op.kind != '+' {
+				oldLine++
+			}
+			if op.kind != '-' {
+				newLine++
+			}
+		}
+		oldCount, newCount := 0, 0
+		for _, op := range ops[hunkStart:hunkEnd] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+		for _, op := range ops[hunkStart:hunkEnd] {
+			b.WriteRune(op.kind)
+			b.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = hunkEnd
+	}
+	return b.String()
+}
+
+func hunkRange(line int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", line-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+// splitLines splits the content into lines keeping the line endings
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines finds the shortest edit script turning the old lines into the new lines with the Myers algorithm
+func diffLines(oldLines []string, newLines []string) []diffOp {
+	n, m := len(oldLines), len(newLines)
+	maxEdits := n + m
+	offset := maxEdits + 1
+	v := make([]int, 2*maxEdits+2)
+	trace := make([][]int, 0)
+	for d := 0; d <= maxEdits; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		done := false
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && oldLines[x] == newLines[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				done = true
+				break
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	// walk the trace back from the end to recover the edit script
+	ops := make([]diffOp, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{kind: ' ', line: oldLines[x]})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			ops = append(ops, diffOp{kind: '+', line: newLines[y]})
+		} else {
+			x--
+			ops = append(ops, diffOp{kind: '-', line: oldLines[x]})
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
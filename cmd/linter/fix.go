@@ -0,0 +1,432 @@
+package linter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+
+	"github.com/CrowdStrike/gql/cmd/linter/lexer"
+	"github.com/CrowdStrike/gql/pkg/linter"
+	"github.com/CrowdStrike/gql/utils"
+)
+
+// textEdit replaces the runes between start and end of a schema file with text, start == end inserts text
+type textEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// schemaFile is a parsed schema file along with its tokens, comments left out
+type schemaFile struct {
+	name    string
+	content string
+	doc     *ast.SchemaDocument
+	tokens  []lexer.Token
+	tokenAt map[int]int
+	fixes   []linter.Fix
+	edits   []textEdit
+}
+
+// FixSchemaFiles applies the fixes of the rules to the schema files and returns the contents of the files which changed.
+// Fixes for lint errors disabled with inline lint config are not applied, renamed types are renamed in all the files.
+// Introspection results are left as they are.
+func FixSchemaFiles(schemaFileContents map[string][]byte, rulesToApply map[string][]linter.LintRuleMetadata) (map[string][]byte, error) {
+	fileNames := make([]string, 0, len(schemaFileContents))
+	for fileName := range schemaFileContents {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	files := make([]*schemaFile, 0, len(fileNames))
+	definitions := make(map[string]*ast.Definition)
+	directiveDefinitions := make(map[string]*ast.DirectiveDefinition)
+	for _, fileName := range fileNames {
+		if utils.IsIntrospectionJSON(fileName, schemaFileContents[fileName]) {
+			// introspection results are not schema sources which can be fixed
+			continue
+		}
+		file, err := newSchemaFile(fileName, string(schemaFileContents[fileName]))
+		if err != nil {
+			return nil, err
+		}
+		for _, definition := range file.doc.Definitions {
+			definitions[definition.Name] = definition
+		}
+		for _, directiveDefinition := range file.doc.Directives {
+			directiveDefinitions[directiveDefinition.Name] = directiveDefinition
+		}
+		file.fixes = findFixes(file, rulesToApply[fileName])
+		files = append(files, file)
+	}
+
+	// type renames are collected first since references to the types can be in any file
+	typeRenames := make(map[string]string)
+	for _, file := range files {
+		for _, fix := range file.fixes {
+			if fix.Kind != linter.RenameFix || fix.Element != linter.TypeElement {
+				continue
+			}
+			if _, exists := definitions[fix.NewName]; exists {
+				// renaming would collide with an existing type
+				continue
+			}
+			typeRenames[fix.Name] = fix.NewName
+		}
+	}
+
+	fixedContents := make(map[string][]byte)
+	for _, file := range files {
+		for _, index := range file.typeReferences() {
+			if newName, ok := typeRenames[file.tokens[index].Value]; ok {
+				file.replaceToken(index, newName)
+			}
+		}
+		for _, fix := range file.fixes {
+			switch fix.Kind {
+			case linter.RenameFix:
+				if fix.Element == linter.TypeElement {
+					continue
+				}
+				if index := file.nameToken(fix.Position, fix.Name); index >= 0 {
+					file.replaceToken(index, fix.NewName)
+				}
+				if fix.Element == linter.EnumValueElement {
+					for _, referencingFile := range files {
+						referencingFile.renameEnumValueReferences(fix, definitions, directiveDefinitions)
+					}
+				}
+			case linter.DescriptionFix:
+				file.addDescription(fix)
+			}
+		}
+	}
+	for _, file := range files {
+		if fixed := applyEdits(file.content, file.edits); fixed != file.content {
+			fixedContents[file.name] = []byte(fixed)
+		}
+	}
+	return fixedContents, nil
+}
+
+func newSchemaFile(fileName string, content string) (*schemaFile, error) {
+	source := &ast.Source{
+		Name:  fileName,
+		Input: content,
+	}
+	doc, parseErr := parser.ParseSchema(source)
+	if parseErr != nil {
+		return nil, fmt.Errorf("failed to parse file=%s with error %v", fileName, parseErr)
+	}
+	file := &schemaFile{
+		name:    fileName,
+		content: content,
+		doc:     doc,
+		tokenAt: make(map[int]int),
+	}
+	s := lexer.New(source)
+	for {
+		token, err := s.ReadToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tokens of file=%s with error %v", fileName, err)
+		}
+		if token.Kind == lexer.EOF {
+			break
+		}
+		if token.Kind == lexer.Comment {
+			continue
+		}
+		file.tokenAt[token.Pos.Start] = len(file.tokens)
+		file.tokens = append(file.tokens, token)
+	}
+	return file, nil
+}
+
+// findFixes finds the fixes of the rules which resolve lint errors that are not disabled with inline lint config
+func findFixes(file *schemaFile, rules []linter.LintRuleMetadata) []linter.Fix {
+	lintErrors := LintWithRules(file.name, file.content, rules)
+	reported := make(map[string]bool, len(lintErrors))
+	for _, lintErr := range lintErrors {
+		reported[fmt.Sprintf("%s:%d", lintErr.Rule, lintErr.Line)] = true
+	}
+
+	fixes := make([]linter.Fix, 0)
+	for _, rule := range rules {
+		if rule.FixFunction == nil {
+			continue
+		}
+		for _, fix := range rule.FixFunction(file.doc) {
+			if reported[fmt.Sprintf("%s:%d", fix.Rule, fix.Line)] {
+				fixes = append(fixes, fix)
+			}
+		}
+	}
+	return fixes
+}
+
+// nameToken returns the index of the name token of the element at the given position, the description of the element is skipped
+func (f *schemaFile) nameToken(position *ast.Position, name string) int {
+	index, ok := f.tokenAt[position.Start]
+	if !ok {
+		return -1
+	}
+	if kind := f.tokens[index].Kind; (kind == lexer.String || kind == lexer.BlockString) && index+1 < len(f.tokens) {
+		index++
+	}
+	if f.tokens[index].Kind != lexer.Name || f.tokens[index].Value != name {
+		return -1
+	}
+	return index
+}
+
+func (f *schemaFile) replaceToken(index int, text string) {
+	token := f.tokens[index]
+	f.edits = append(f.edits, textEdit{start: token.Pos.Start, end: token.Pos.End, text: text})
+}
+
+// typeReferences returns the indexes of all the tokens naming a type, type definitions and extensions included
+func (f *schemaFile) typeReferences() []int {
+	indexes := make([]int, 0)
+	var addType func(typ *ast.Type)
+	addType = func(typ *ast.Type) {
+		if typ == nil || typ.Position == nil {
+			return
+		}
+		if typ.Elem != nil {
+			addType(typ.Elem)
+			return
+		}
+		if index, ok := f.tokenAt[typ.Position.Start]; ok && f.tokens[index].Kind == lexer.Name {
+			indexes = append(indexes, index)
+		}
+	}
+	addArguments := func(arguments ast.ArgumentDefinitionList) {
+		for _, argument := range arguments {
+			addType(argument.Type)
+		}
+	}
+
+	for _, definitions := range []ast.DefinitionList{f.doc.Definitions, f.doc.Extensions} {
+		for _, definition := range definitions {
+			index := f.nameToken(definition.Position, definition.Name)
+			if index < 0 {
+				continue
+			}
+			indexes = append(indexes, index)
+			if len(definition.Interfaces) != 0 {
+				indexes = append(indexes, f.implementedInterfaces(index)...)
+			}
+			if len(definition.Types) != 0 {
+				indexes = append(indexes, f.unionMembers(index)...)
+			}
+			for _, field := range definition.Fields {
+				addType(field.Type)
+				addArguments(field.Arguments)
+			}
+		}
+	}
+	for _, directiveDefinition := range f.doc.Directives {
+		addArguments(directiveDefinition.Arguments)
+	}
+	for _, schemaDefinitions := range []ast.SchemaDefinitionList{f.doc.Schema, f.doc.SchemaExtension} {
+		for _, schemaDefinition := range schemaDefinitions {
+			for _, operationType := range schemaDefinition.OperationTypes {
+				// operation type is followed by a colon and then the type name
+				if index, ok := f.tokenAt[operationType.Position.Start]; ok && index+2 < len(f.tokens) && f.tokens[index+2].Kind == lexer.Name {
+					indexes = append(indexes, index+2)
+				}
+			}
+		}
+	}
+	return indexes
+}
+
+// implementedInterfaces returns the indexes of the interface names after the implements keyword following the type name
+func (f *schemaFile) implementedInterfaces(nameIndex int) []int {
+	indexes := make([]int, 0)
+	i := nameIndex + 1
+	if i >= len(f.tokens) || f.tokens[i].Kind != lexer.Name || f.tokens[i].Value != "implements" {
+		return indexes
+	}
+	i++
+	if i < len(f.tokens) && f.tokens[i].Kind == lexer.Amp {
+		i++
+	}
+	for ; i < len(f.tokens) && f.tokens[i].Kind == lexer.Name; i++ {
+		indexes = append(indexes, i)
+		if i+1 >= len(f.tokens) || f.tokens[i+1].Kind != lexer.Amp {
+			break
+		}
+		i++
+	}
+	return indexes
+}
+
+// unionMembers returns the indexes of the member type names after the equals sign following the union name
+func (f *schemaFile) unionMembers(nameIndex int) []int {
+	indexes := make([]int, 0)
+	i := nameIndex + 1
+	for i < len(f.tokens) && f.tokens[i].Kind != lexer.Equals {
+		i++
+	}
+	i++
+	if i < len(f.tokens) && f.tokens[i].Kind == lexer.Pipe {
+		i++
+	}
+	for ; i < len(f.tokens) && f.tokens[i].Kind == lexer.Name; i++ {
+		indexes = append(indexes, i)
+		if i+1 >= len(f.tokens) || f.tokens[i+1].Kind != lexer.Pipe {
+			break
+		}
+		i++
+	}
+	return indexes
+}
+
+// renameEnumValueReferences renames the enum value in default values and directive arguments of the enum type
+func (f *schemaFile) renameEnumValueReferences(fix linter.Fix, definitions map[string]*ast.Definition, directiveDefinitions map[string]*ast.DirectiveDefinition) {
+	var renameValue func(value *ast.Value, typ *ast.Type)
+	renameValue = func(value *ast.Value, typ *ast.Type) {
+		if value == nil || typ == nil {
+			return
+		}
+		switch value.Kind {
+		case ast.EnumValue:
+			if typ.Name() == fix.Parent && value.Raw == fix.Name && value.Position != nil {
+				if index, ok := f.tokenAt[value.Position.Start]; ok {
+					f.replaceToken(index, fix.NewName)
+				}
+			}
+		case ast.ListValue:
+			elemType := typ
+			if typ.Elem != nil {
+				elemType = typ.Elem
+			}
+			for _, child := range value.Children {
+				renameValue(child.Value, elemType)
+			}
+		case ast.ObjectValue:
+			inputDefinition := definitions[typ.Name()]
+			if inputDefinition == nil {
+				return
+			}
+			for _, child := range value.Children {
+				if field := inputDefinition.Fields.ForName(child.Name); field != nil {
+					renameValue(child.Value, field.Type)
+				}
+			}
+		}
+	}
+	renameDirectives := func(directives ast.DirectiveList) {
+		for _, directive := range directives {
+			directiveDefinition := directiveDefinitions[directive.Name]
+			if directiveDefinition == nil {
+				continue
+			}
+			for _, argument := range directive.Arguments {
+				if argumentDefinition := directiveDefinition.Arguments.ForName(argument.Name); argumentDefinition != nil {
+					renameValue(argument.Value, argumentDefinition.Type)
+				}
+			}
+		}
+	}
+
+	for _, definitions := range []ast.DefinitionList{f.doc.Definitions, f.doc.Extensions} {
+		for _, definition := range definitions {
+			renameDirectives(definition.Directives)
+			for _, field := range definition.Fields {
+				renameValue(field.DefaultValue, field.Type)
+				renameDirectives(field.Directives)
+				for _, argument := range field.Arguments {
+					renameValue(argument.DefaultValue, argument.Type)
+					renameDirectives(argument.Directives)
+				}
+			}
+			for _, enumValue := range definition.EnumValues {
+				renameDirectives(enumValue.Directives)
+			}
+		}
+	}
+	for _, directiveDefinition := range f.doc.Directives {
+		for _, argument := range directiveDefinition.Arguments {
+			renameValue(argument.DefaultValue, argument.Type)
+		}
+	}
+	for _, schemaDefinitions := range []ast.SchemaDefinitionList{f.doc.Schema, f.doc.SchemaExtension} {
+		for _, schemaDefinition := range schemaDefinitions {
+			renameDirectives(schemaDefinition.Directives)
+		}
+	}
+}
+
+// addDescription adds the placeholder description before the element, on its own line if the element starts the line
+func (f *schemaFile) addDescription(fix linter.Fix) {
+	index, ok := f.tokenAt[fix.Position.Start]
+	if !ok {
+		return
+	}
+	description := fmt.Sprintf("%q", linter.PlaceholderDescription)
+	if fix.Element == linter.TypeElement {
+		// type position is at the name so the description goes before the keyword
+		if index == 0 {
+			return
+		}
+		index--
+		if index > 0 && isEmptyDescription(f.tokens[index-1]) {
+			f.replaceToken(index-1, description)
+			return
+		}
+	} else if isEmptyDescription(f.tokens[index]) {
+		f.replaceToken(index, description)
+		return
+	}
+
+	start := f.tokens[index].Pos.Start
+	runes := []rune(f.content)
+	lineStart := start
+	for lineStart > 0 && runes[lineStart-1] != '\n' {
+		lineStart--
+	}
+	indent := string(runes[lineStart:start])
+	if len(strings.TrimSpace(indent)) == 0 {
+		f.edits = append(f.edits, textEdit{start: start, end: start, text: description + "\n" + indent})
+		return
+	}
+	f.edits = append(f.edits, textEdit{start: start, end: start, text: description + " "})
+}
+
+func isEmptyDescription(token lexer.Token) bool {
+	return (token.Kind == lexer.String || token.Kind == lexer.BlockString) && len(token.Value) == 0
+}
+
+// applyEdits applies the edits from the end of the content so that rune offsets of the remaining edits stay valid
+func applyEdits(content string, edits []textEdit) string {
+	if len(edits) == 0 {
+		return content
+	}
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].start != edits[j].start {
+			return edits[i].start > edits[j].start
+		}
+		// replacing a name goes before inserting a description in front of it
+		return edits[i].end > edits[j].end
+	})
+	runes := []rune(content)
+	var previous *textEdit
+	for i := range edits {
+		edit := edits[i]
+		if previous != nil && *previous == edit {
+			continue
+		}
+		fixed := make([]rune, 0, len(runes)+len(edit.text))
+		fixed = append(fixed, runes[:edit.start]...)
+		fixed = append(fixed, []rune(edit.text)...)
+		fixed = append(fixed, runes[edit.end:]...)
+		runes = fixed
+		previous = &edits[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,203 @@
+package linter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/CrowdStrike/gql/pkg/linter"
+)
+
+func TestFixSchemaFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  []string
+		schema map[string]string
+		want   map[string]string
+	}{
+		{
+			"renamed_types_are_renamed_in_all_files",
+			[]string{"type-caps"},
+			map[string]string{
+				"book.graphql": `# books
+type book implements node & named {
+  id: ID!
+  similar: [[book!]]
+}
+`,
+				"query.graphql": `schema { query: query }
+type query { books(filter: bookFilter): [book] } # keep me
+interface node { id: ID! }
+interface named { name: String }
+input bookFilter { title: String }
+union result = | book | query
+extend type book { extra: result }
+`,
+			},
+			map[string]string{
+				"book.graphql": `# books
+type Book implements Node & Named {
+  id: ID!
+  similar: [[Book!]]
+}
+`,
+				"query.graphql": `schema { query: Query }
+type Query { books(filter: BookFilter): [Book] } # keep me
+interface Node { id: ID! }
+interface Named { name: String }
+input BookFilter { title: String }
+union Result = | Book | Query
+extend type Book { extra: Result }
+`,
+			},
+		},
+		{
+			"renamed_enum_values_are_renamed_in_default_values",
+			[]string{"enum-caps"},
+			map[string]string{
+				"schema.graphql": `enum Status { inProgress, DONE }
+directive @status(value: Status = inProgress) on FIELD_DEFINITION
+input Filter { statuses: [Status!] = [inProgress, DONE] }
+type Query {
+  books(status: Status = inProgress, filter: Filter = {statuses: [inProgress]}): String @status(value: inProgress)
+  inProgress: String
+}
+`,
+			},
+			map[string]string{
+				"schema.graphql": `enum Status { IN_PROGRESS, DONE }
+directive @status(value: Status = IN_PROGRESS) on FIELD_DEFINITION
+input Filter { statuses: [Status!] = [IN_PROGRESS, DONE] }
+type Query {
+  books(status: Status = IN_PROGRESS, filter: Filter = {statuses: [IN_PROGRESS]}): String @status(value: IN_PROGRESS)
+  inProgress: String
+}
+`,
+			},
+		},
+		{
+			"fields_are_camel_cased_unless_disabled",
+			[]string{"field-camel"},
+			map[string]string{
+				"schema.graphql": `type Query {
+  "all the books"
+  ALL_BOOKS: [String]
+  book_title: String #lint-disable-line field-camel
+  bookTitle: String
+  author_name: String
+}
+`,
+			},
+			map[string]string{
+				"schema.graphql": `type Query {
+  "all the books"
+  allBooks: [String]
+  book_title: String #lint-disable-line field-camel
+  bookTitle: String
+  authorName: String
+}
+`,
+			},
+		},
+		{
+			"descriptions_are_added",
+			[]string{"type-desc", "field-desc", "args-desc", "enum-desc"},
+			map[string]string{
+				"schema.graphql": `# root
+type Query {
+    books(first: Int): [String]
+  "" title: String
+}
+enum Status { DONE }
+`,
+			},
+			map[string]string{
+				"schema.graphql": `# root
+"TODO: add description"
+type Query {
+    "TODO: add description"
+    books("TODO: add description" first: Int): [String]
+  "TODO: add description" title: String
+}
+"TODO: add description"
+enum Status { "TODO: add description" DONE }
+`,
+			},
+		},
+		{
+			"nothing_to_fix",
+			[]string{"relay-conn-type", "enum-caps"},
+			map[string]string{
+				"schema.graphql": `type BookConnection { total: Int }
+enum Status { inProgress } #lint-disable-line enum-caps
+`,
+			},
+			map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := FindTheRulesWithSeverity(tt.rules)
+			if err != nil {
+				t.Fatalf("FindTheRulesWithSeverity() error = %v", err)
+			}
+			schemaFileContents := make(map[string][]byte)
+			rulesToApply := make(map[string][]linter.LintRuleMetadata)
+			for fileName, content := range tt.schema {
+				schemaFileContents[fileName] = []byte(content)
+				rulesToApply[fileName] = rules
+			}
+			fixedContents, err := FixSchemaFiles(schemaFileContents, rulesToApply)
+			if err != nil {
+				t.Fatalf("FixSchemaFiles() error = %v", err)
+			}
+			got := make(map[string]string)
+			for fileName, content := range fixedContents {
+				got[fileName] = string(content)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FixSchemaFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldContent string
+		newContent string
+		want       string
+	}{
+		{
+			"same_content",
+			"type Query { a: Int }\n",
+			"type Query { a: Int }\n",
+			"",
+		},
+		{
+			"single_hunk",
+			"a\nb\nc\nd\n",
+			"a\nB\nc\nd\ne\n",
+			"--- a/schema.graphql\n+++ b/schema.graphql\n@@ -1,4 +1,5 @@\n a\n-b\n+B\n c\n d\n+e\n",
+		},
+		{
+			"separate_hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			"one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			"--- a/schema.graphql\n+++ b/schema.graphql\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+		{
+			"no_newline_at_end",
+			"a\nb",
+			"a\nc",
+			"--- a/schema.graphql\n+++ b/schema.graphql\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff("schema.graphql", tt.oldContent, tt.newContent); got != tt.want {
+				t.Errorf("UnifiedDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
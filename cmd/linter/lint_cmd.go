@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/CrowdStrike/gql/pkg/config"
 	"github.com/CrowdStrike/gql/pkg/linter"
 	"github.com/CrowdStrike/gql/utils"
 	"github.com/spf13/cobra"
@@ -14,6 +16,18 @@ import (
 var (
 	schemaFilePath string
 	passedRules    []string
+	outputFormat   string
+	configPath     string
+	maxWarnings    int
+	fix            bool
+	fixDryRun      bool
+	endpoint       string
+	headers        []string
+)
+
+const (
+	textFormat  = "text"
+	sarifFormat = "sarif"
 )
 
 // NewLintCmd creates new lint command
@@ -26,14 +40,54 @@ func NewLintCmd() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			schemaFileContents := make(map[string][]byte)
 
-			rulesToApply, err := FindTheRulesToApply(passedRules)
+			if outputFormat != textFormat && outputFormat != sarifFormat {
+				fmt.Printf("unsupported output format '%s', expected one of: text, sarif\n", outputFormat)
+				os.Exit(1)
+			}
+			if fixDryRun && outputFormat == sarifFormat {
+				fmt.Printf("--fix-dry-run can not be used with the sarif output format\n")
+				os.Exit(1)
+			}
+
+			cfg, err := config.Resolve(configPath)
 			if err != nil {
-				fmt.Printf("failed to parse the rules to apply string=%s, error:%v", passedRules, err)
-				//Exit with error printed to stderr
+				fmt.Printf("failed to load config file, error:%v", err)
 				os.Exit(1)
 			}
 
-			if len(schemaFilePath) == 0 {
+			for _, declarativeRule := range cfg.Lint.CustomRules {
+				rule, err := declarativeRule.Compile()
+				if err == nil {
+					err = linter.RegisterRule(rule)
+				}
+				if err != nil {
+					fmt.Printf("failed to register custom rule from config file, error:%v", err)
+					os.Exit(1)
+				}
+			}
+
+			schemaFilePaths := []string{schemaFilePath}
+			if len(schemaFilePath) == 0 && len(cfg.Lint.Schema) != 0 {
+				schemaFilePaths = schemaFilePaths[:0]
+				for _, schemaGlob := range cfg.Lint.Schema {
+					schemaFilePaths = append(schemaFilePaths, cfg.ResolvePath(schemaGlob))
+				}
+			}
+
+			if len(endpoint) != 0 {
+				contents, err := utils.ReadEndpoint(endpoint, headers)
+				if err != nil {
+					fmt.Printf("failed to read schema from endpoint, error:%v", err)
+					os.Exit(1)
+				}
+				for url, content := range contents {
+					schemaFileContents[url] = content
+				}
+			} else if len(schemaFilePaths[0]) == 0 {
+				if fix {
+					fmt.Printf("--fix can not be used with schema read from stdin, use --fix-dry-run instead\n")
+					os.Exit(1)
+				}
 				content, err := io.ReadAll(os.Stdin)
 				if err != nil {
 					fmt.Printf("failed to input from stdin with error %v", err)
@@ -41,38 +95,134 @@ func NewLintCmd() *cobra.Command {
 				}
 				schemaFileContents[os.Stdin.Name()] = content
 			} else {
-				var err error
-				schemaFileContents, err = utils.ReadFiles(schemaFilePath)
+				for _, path := range schemaFilePaths {
+					contents, err := utils.ReadFiles(path)
+					if err != nil {
+						fmt.Printf("failed to read schema file error %v", err)
+						os.Exit(1)
+					}
+					for filename, content := range contents {
+						schemaFileContents[filename] = content
+					}
+				}
+			}
+
+			if !cmd.Flags().Changed("max-warnings") && cfg.Lint.MaxWarnings != nil {
+				maxWarnings = *cfg.Lint.MaxWarnings
+			}
+
+			rulesToApply := make(map[string][]linter.LintRuleMetadata, len(schemaFileContents))
+			for filename := range schemaFileContents {
+				var rules []linter.LintRuleMetadata
+				if cmd.Flags().Changed("rules") {
+					// rules passed on the command line override the config file
+					rules, err = FindTheRulesWithSeverity(passedRules)
+				} else {
+					rules, err = FindTheRulesForFile(cfg, filename)
+				}
 				if err != nil {
-					fmt.Printf("failed to read schema file error %v", err)
+					fmt.Printf("failed to find the rules to apply for file=%s, error:%v", filename, err)
+					//Exit with error printed to stderr
+					os.Exit(1)
+				}
+				rulesToApply[filename] = rules
+			}
+
+			if fix || fixDryRun {
+				fixedContents, err := FixSchemaFiles(schemaFileContents, rulesToApply)
+				if err != nil {
+					fmt.Printf("failed to fix schema files, error:%v", err)
+					os.Exit(1)
+				}
+				if err := presentFixes(schemaFileContents, fixedContents, fixDryRun); err != nil {
+					fmt.Printf("failed to write fixed schema file, error:%v", err)
 					os.Exit(1)
 				}
+				// lint errors left after fixing are reported as usual
+				for filename, content := range fixedContents {
+					schemaFileContents[filename] = content
+				}
 			}
 
-			exitStatus := FindLintErrors(schemaFileContents, rulesToApply)
+			exitStatus := ReportLintErrors(schemaFileContents, rulesToApply, outputFormat, maxWarnings)
 
 			os.Exit(exitStatus) // success
 		},
 	}
 	lintCmd.PersistentFlags().StringVarP(&schemaFilePath, "filepath", "f", "", "Path to your GraphQL schema")
-	lintCmd.PersistentFlags().StringSliceVarP(&passedRules, "rules", "r", []string{}, fmt.Sprintf("Rules you want linter to use e.g.(-r type-desc,field-desc); available rules:\n %s", linter.AvailableRulesWithDescription()))
+	lintCmd.PersistentFlags().StringVar(&endpoint, "endpoint", "", "URL of the GraphQL endpoint to read the schema from with the introspection query, instead of schema files")
+	lintCmd.PersistentFlags().StringArrayVarP(&headers, "header", "H", []string{}, "Header sent to the GraphQL endpoint e.g.(-H 'Authorization: Bearer token'), can be passed multiple times")
+	lintCmd.PersistentFlags().StringSliceVarP(&passedRules, "rules", "r", []string{}, fmt.Sprintf("Rules you want linter to use with optional severity e.g.(-r type-desc,field-desc:warn); available rules:\n %s", linter.AvailableRulesWithDescription()))
+	lintCmd.PersistentFlags().StringVar(&outputFormat, "format", textFormat, "Output format, one of: text, sarif")
+	lintCmd.PersistentFlags().IntVar(&maxWarnings, "max-warnings", -1, "Number of warnings to trigger nonzero exit code, -1 allows any number of warnings")
+	lintCmd.PersistentFlags().BoolVar(&fix, "fix", false, "Fix mechanical lint errors in place e.g. enum value casing and missing descriptions")
+	lintCmd.PersistentFlags().BoolVar(&fixDryRun, "fix-dry-run", false, "Print the fixes --fix would make as a unified diff without changing the files")
+	lintCmd.PersistentFlags().StringVar(&configPath, "config", "", fmt.Sprintf("Path to the config file, by default the first of %s found walking up from the working directory", strings.Join(config.FileNames, ", ")))
 	return lintCmd
 }
 
 // FindLintErrors find lint errors in schema files applying the supplied rules
 func FindLintErrors(schemaFileContents map[string][]byte, rulesToApply []linter.LintRuleFunc) int {
+	rules := ruleMetadata(rulesToApply)
+	rulesByFile := make(map[string][]linter.LintRuleMetadata, len(schemaFileContents))
+	for filename := range schemaFileContents {
+		rulesByFile[filename] = rules
+	}
+	return ReportLintErrors(schemaFileContents, rulesByFile, textFormat, -1)
+}
+
+// ReportLintErrors find lint errors in schema files applying the rules of every file and presents them in the given output format.
+// Lint errors of rules with error severity fail the lint, warnings fail it only when there are more than maxWarnings of them.
+func ReportLintErrors(schemaFileContents map[string][]byte, rulesToApply map[string][]linter.LintRuleMetadata, format string, maxWarnings int) int {
 	exitStatus := 0
 	errorCount := 0
+	warningCount := 0
+	lintErrorsByFile := make(map[string][]linter.LintErrorWithMetadata)
 	for filename, schemaFileContent := range schemaFileContents {
-		if lintErrors := Lint(filename, string(schemaFileContent), rulesToApply); len(lintErrors) != 0 {
-			errorCount += len(lintErrors)
-			errorPresenter(filename, lintErrors)
-			exitStatus |= 1 // If there's error for any file, exit code should be 1
+		lintErrors := make([]linter.LintErrorWithMetadata, 0)
+		for _, lintErr := range LintWithRules(filename, string(schemaFileContent), rulesToApply[filename]) {
+			switch lintErr.Severity {
+			case linter.SeverityOff:
+				// lint errors which are turned off are neither reported nor counted
+				continue
+			case linter.SeverityWarning:
+				warningCount++
+			default:
+				errorCount++
+			}
+			lintErrors = append(lintErrors, lintErr)
+		}
+		if len(lintErrors) == 0 {
+			continue
+		}
+		lintErrorsByFile[filename] = lintErrors
+	}
+	if errorCount != 0 {
+		exitStatus |= 1 // If there's error for any file, exit code should be 1
+	}
+	if maxWarnings >= 0 && warningCount > maxWarnings {
+		exitStatus |= 1
+	}
+	if format == sarifFormat {
+		if err := linter.WriteSarifReport(os.Stdout, lintErrorsByFile); err != nil {
+			fmt.Printf("failed to write sarif report, error:%v", err)
+			return 1
 		}
+		return exitStatus
 	}
-	if errorCount == 0 {
+	for filename, lintErrors := range lintErrorsByFile {
+		errorPresenter(filename, lintErrors)
+	}
+	if errorCount == 0 && warningCount == 0 {
 		fmt.Printf("Schema has no lint errors! 🎉\n")
-	} else {
+	}
+	if warningCount != 0 {
+		fmt.Printf("✋️ Total lint warnings found: %d\n", warningCount)
+		if maxWarnings >= 0 && warningCount > maxWarnings {
+			fmt.Printf("❌ Too many lint warnings, maximum allowed: %d\n", maxWarnings)
+		}
+	}
+	if errorCount != 0 {
 		fmt.Printf("❌ Total lint errors found: %d\n", errorCount)
 	}
 	return exitStatus
@@ -80,33 +230,145 @@ func FindLintErrors(schemaFileContents map[string][]byte, rulesToApply []linter.
 
 // FindTheRulesToApply convert matching string rules to LintRuleFunc
 func FindTheRulesToApply(rulesString []string) ([]linter.LintRuleFunc, error) {
-	rulesToApply := make([]linter.LintRuleFunc, 0)
+	rules, err := FindTheRulesWithSeverity(rulesString)
+	if err != nil {
+		return nil, err
+	}
+	rulesToApply := make([]linter.LintRuleFunc, 0, len(rules))
+	for _, rule := range rules {
+		rulesToApply = append(rulesToApply, rule.RuleFunction)
+	}
+	return rulesToApply, nil
+}
+
+// FindTheRulesWithSeverity convert matching string rules to LintRuleMetadata.
+// A rule can be suffixed with its severity e.g. field-desc:warn, rules with off severity are left out.
+func FindTheRulesWithSeverity(rulesString []string) ([]linter.LintRuleMetadata, error) {
+	rulesToApply := make([]linter.LintRuleMetadata, 0)
 	if len(rulesString) == 0 {
 		for _, rule := range linter.AllTheRules {
-			rulesToApply = append(rulesToApply, rule.RuleFunction)
+			if rule.Severity != linter.SeverityOff {
+				rulesToApply = append(rulesToApply, rule)
+			}
 		}
 		return rulesToApply, nil
 	}
 	for _, ruleToken := range rulesString {
 		inputRuleName := strings.TrimSpace(ruleToken)
-		// Check whether the rule passed exists in our rule list
-		matchFound := false
+		var severity linter.Severity
+		if i := strings.IndexByte(inputRuleName, ':'); i >= 0 {
+			var err error
+			if severity, err = linter.ParseSeverity(inputRuleName[i+1:]); err != nil {
+				return nil, err
+			}
+			inputRuleName = strings.TrimSpace(inputRuleName[:i])
+		}
+		rule, err := findRule(inputRuleName)
+		if err != nil {
+			return nil, err
+		}
+		if len(severity) != 0 {
+			rule.Severity = severity
+		}
+		if rule.Severity != linter.SeverityOff {
+			rulesToApply = append(rulesToApply, rule)
+		}
+	}
+	return rulesToApply, nil
+}
+
+// FindTheRulesForFile resolves the rules to apply to a schema file from the config file
+func FindTheRulesForFile(cfg *config.Config, filename string) ([]linter.LintRuleMetadata, error) {
+	settings := cfg.LintSettingsForFile(filename)
+	for _, ruleName := range settings.Disabled {
+		if _, err := findRule(ruleName); err != nil {
+			return nil, err
+		}
+	}
+
+	enabled := settings.Rules
+	if len(enabled) == 0 {
 		for _, rule := range linter.AllTheRules {
-			if strings.EqualFold(inputRuleName, string(rule.Name)) {
-				matchFound = true
-				rulesToApply = append(rulesToApply, rule.RuleFunction)
-				break
+			enabled = append(enabled, string(rule.Name))
+		}
+	}
+	ruleNames := make([]string, 0, len(enabled))
+	for _, ruleName := range enabled {
+		if !contains(settings.Disabled, linter.LintRule(strings.TrimSpace(ruleName))) {
+			ruleNames = append(ruleNames, ruleName)
+		}
+	}
+	if len(ruleNames) == 0 {
+		// every rule is disabled for this file
+		return []linter.LintRuleMetadata{}, nil
+	}
+	rules, err := FindTheRulesWithSeverity(ruleNames)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesToApply := make([]linter.LintRuleMetadata, 0, len(rules))
+	for _, rule := range rules {
+		for ruleName, severityName := range settings.Severity {
+			if !strings.EqualFold(ruleName, string(rule.Name)) {
+				continue
+			}
+			severity, err := linter.ParseSeverity(severityName)
+			if err != nil {
+				return nil, fmt.Errorf("rule[%s]: %v", ruleName, err)
 			}
+			rule.Severity = severity
 		}
-		if !matchFound {
-			return nil, fmt.Errorf("invalid rule[%s] passed", inputRuleName)
+		if rule.Severity != linter.SeverityOff {
+			rulesToApply = append(rulesToApply, rule)
 		}
 	}
 	return rulesToApply, nil
 }
 
+func findRule(ruleName string) (linter.LintRuleMetadata, error) {
+	// Check whether the rule passed exists in our rule list
+	for _, rule := range linter.AllTheRules {
+		if strings.EqualFold(strings.TrimSpace(ruleName), string(rule.Name)) {
+			return rule, nil
+		}
+	}
+	return linter.LintRuleMetadata{}, fmt.Errorf("invalid rule[%s] passed", ruleName)
+}
+
+// presentFixes writes the fixed schema files, or prints their diff when it's a dry run
+func presentFixes(schemaFileContents map[string][]byte, fixedContents map[string][]byte, dryRun bool) error {
+	fileNames := make([]string, 0, len(fixedContents))
+	for filename := range fixedContents {
+		fileNames = append(fileNames, filename)
+	}
+	sort.Strings(fileNames)
+	for _, filename := range fileNames {
+		if dryRun {
+			fmt.Print(UnifiedDiff(filename, string(schemaFileContents[filename]), string(fixedContents[filename])))
+			continue
+		}
+		info, err := os.Stat(filename)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filename, fixedContents[filename], info.Mode().Perm()); err != nil {
+			return err
+		}
+		fmt.Printf("🔧 Fixed %s\n", filename)
+	}
+	if dryRun && len(fileNames) != 0 {
+		fmt.Println("") // This is a separator between the diff and the lint errors
+	}
+	return nil
+}
+
 func errorPresenter(schemaFilePath string, errors []linter.LintErrorWithMetadata) {
 	for _, err := range errors {
+		if err.Severity == linter.SeverityWarning {
+			fmt.Printf("%s:%d:%d warning: %s\n", schemaFilePath, err.Line, err.Column, err.Err.Error())
+			continue
+		}
 		fmt.Printf("%s:%d:%d %s\n", schemaFilePath, err.Line, err.Column, err.Err.Error())
 	}
 	fmt.Println("") // This is a separator between outputs of individual file
@@ -0,0 +1,233 @@
+package linter
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/CrowdStrike/gql/pkg/linter"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// findingsRule is a rule reporting the number of lint errors on the first definition of the schema
+func findingsRule(name linter.LintRule, severity linter.Severity, findings int) linter.LintRuleMetadata {
+	return linter.LintRuleMetadata{
+		Name:     name,
+		Severity: severity,
+		RuleFunction: func(schema *ast.SchemaDocument) linter.LintErrorsWithMetadata {
+			lintErrors := make([]linter.LintErrorWithMetadata, 0, findings)
+			for i := 0; i < findings; i++ {
+				lintErrors = append(lintErrors, linter.LintErrorWithMetadata{
+					Rule:   name,
+					Line:   schema.Definitions[0].Position.Line,
+					Column: schema.Definitions[0].Position.Column,
+					Err:    fmt.Errorf("%s finding %d", name, i),
+				})
+			}
+			return lintErrors
+		},
+	}
+}
+
+func TestReportLintErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       []linter.LintRuleMetadata
+		maxWarnings int
+		want        int
+	}{
+		{
+			name:        "no lint errors",
+			rules:       []linter.LintRuleMetadata{findingsRule("none", linter.SeverityError, 0)},
+			maxWarnings: -1,
+			want:        0,
+		},
+		{
+			name:        "lint errors fail",
+			rules:       []linter.LintRuleMetadata{findingsRule("errors", linter.SeverityError, 1)},
+			maxWarnings: -1,
+			want:        1,
+		},
+		{
+			name:        "lint errors fail within max warnings",
+			rules:       []linter.LintRuleMetadata{findingsRule("errors", linter.SeverityError, 1), findingsRule("warnings", linter.SeverityWarning, 1)},
+			maxWarnings: 5,
+			want:        1,
+		},
+		{
+			name:        "any number of warnings is allowed",
+			rules:       []linter.LintRuleMetadata{findingsRule("warnings", linter.SeverityWarning, 3)},
+			maxWarnings: -1,
+			want:        0,
+		},
+		{
+			name:        "warnings up to max warnings pass",
+			rules:       []linter.LintRuleMetadata{findingsRule("warnings", linter.SeverityWarning, 2)},
+			maxWarnings: 2,
+			want:        0,
+		},
+		{
+			name:        "warnings over max warnings fail",
+			rules:       []linter.LintRuleMetadata{findingsRule("warnings", linter.SeverityWarning, 3)},
+			maxWarnings: 2,
+			want:        1,
+		},
+		{
+			name:        "no warnings are allowed",
+			rules:       []linter.LintRuleMetadata{findingsRule("warnings", linter.SeverityWarning, 1)},
+			maxWarnings: 0,
+			want:        1,
+		},
+		{
+			name:        "lint errors turned off are not counted",
+			rules:       []linter.LintRuleMetadata{findingsRule("off", linter.SeverityOff, 2)},
+			maxWarnings: 0,
+			want:        0,
+		},
+	}
+	schemaFileContents := map[string][]byte{"schema.graphql": []byte("type Query { books: [String] }")}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rulesToApply := map[string][]linter.LintRuleMetadata{"schema.graphql": tt.rules}
+			if got := ReportLintErrors(schemaFileContents, rulesToApply, textFormat, tt.maxWarnings); got != tt.want {
+				t.Errorf("ReportLintErrors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindTheRulesWithSeverity(t *testing.T) {
+	allTheRules := linter.AllTheRules
+	defer func() {
+		linter.AllTheRules = allTheRules
+	}()
+	linter.AllTheRules = []linter.LintRuleMetadata{
+		findingsRule("enabled", linter.SeverityError, 1),
+		findingsRule("warned", linter.SeverityWarning, 1),
+		findingsRule("disabled", linter.SeverityOff, 1),
+	}
+	tests := []struct {
+		name  string
+		rules []string
+		want  []linter.LintRule
+	}{
+		{
+			name: "all the rules except the ones turned off",
+			want: []linter.LintRule{"enabled", "warned"},
+		},
+		{
+			name:  "rule turned on with a severity",
+			rules: []string{"disabled:warn"},
+			want:  []linter.LintRule{"disabled"},
+		},
+		{
+			name:  "rule turned off with a severity",
+			rules: []string{"enabled:off", "warned"},
+			want:  []linter.LintRule{"warned"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := FindTheRulesWithSeverity(tt.rules)
+			if err != nil {
+				t.Fatalf("FindTheRulesWithSeverity() error = %v", err)
+			}
+			got := make([]linter.LintRule, 0, len(rules))
+			for _, rule := range rules {
+				got = append(got, rule.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindTheRulesWithSeverity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindLintErrors(t *testing.T) {
+	schemaFileContents := map[string][]byte{"schema.graphql": []byte("type Query { books: [String] }")}
+	tests := []struct {
+		name  string
+		rules []linter.LintRuleFunc
+		want  int
+	}{
+		{
+			name:  "no lint errors",
+			rules: []linter.LintRuleFunc{findingsRule("none", linter.SeverityError, 0).RuleFunction},
+			want:  0,
+		},
+		{
+			name:  "lint errors fail",
+			rules: []linter.LintRuleFunc{findingsRule("errors", linter.SeverityWarning, 1).RuleFunction},
+			want:  1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindLintErrors(schemaFileContents, tt.rules); got != tt.want {
+				t.Errorf("FindLintErrors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindTheRulesToApply(t *testing.T) {
+	allTheRules := linter.AllTheRules
+	defer func() {
+		linter.AllTheRules = allTheRules
+	}()
+	linter.AllTheRules = []linter.LintRuleMetadata{
+		findingsRule("enabled", linter.SeverityError, 1),
+		findingsRule("disabled", linter.SeverityOff, 1),
+	}
+	tests := []struct {
+		name    string
+		rules   []string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "all the rules except the ones turned off",
+			want: 1,
+		},
+		{
+			name:  "rules passed by name",
+			rules: []string{"enabled", "disabled:error"},
+			want:  2,
+		},
+		{
+			name:    "invalid rule",
+			rules:   []string{"unknown"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := FindTheRulesToApply(tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindTheRulesToApply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(rules) != tt.want {
+				t.Errorf("FindTheRulesToApply() returned %d rules, want %d", len(rules), tt.want)
+			}
+		})
+	}
+}
+
+func TestLint(t *testing.T) {
+	rules := []linter.LintRuleFunc{findingsRule("errors", linter.SeverityWarning, 2).RuleFunction}
+	lintErrors := Lint("schema.graphql", "type Query { books: [String] }", rules)
+	if len(lintErrors) != 2 {
+		t.Fatalf("Lint() = %v, want 2 lint errors", lintErrors)
+	}
+	for _, lintErr := range lintErrors {
+		// rule functions without metadata report lint errors with error severity
+		if lintErr.Severity != linter.SeverityError {
+			t.Errorf("Lint() severity = %v, want %v", lintErr.Severity, linter.SeverityError)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/CrowdStrike/gql/pkg/linter"
+	"github.com/CrowdStrike/gql/utils"
 
 	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/parser"
@@ -25,11 +26,25 @@ type InlineLintConfig struct {
 
 // Lint lints federated GraphQL schema
 func Lint(fileName string, schemaFileContents string, rules []linter.LintRuleFunc) []linter.LintErrorWithMetadata {
+	return LintWithRules(fileName, schemaFileContents, ruleMetadata(rules))
+}
+
+// LintWithRules lints federated GraphQL schema, lint errors get the severity of the rule which reported them
+func LintWithRules(fileName string, schemaFileContents string, rules []linter.LintRuleMetadata) []linter.LintErrorWithMetadata {
 	source := &ast.Source{
 		Name:  fileName,
 		Input: schemaFileContents,
 	}
-	schema, parseErr := parser.ParseSchema(source)
+	isIntrospection := utils.IsIntrospectionJSON(fileName, []byte(schemaFileContents))
+	var schema *ast.SchemaDocument
+	var parseErr error
+	if isIntrospection {
+		schema, parseErr = utils.ParseIntrospection(fileName, []byte(schemaFileContents))
+	} else if doc, err := parser.ParseSchema(source); err != nil {
+		parseErr = err
+	} else {
+		schema = doc
+	}
 
 	if parseErr != nil {
 		fmt.Printf("failed to parse file=%s with error %v", fileName, parseErr)
@@ -38,16 +53,34 @@ func Lint(fileName string, schemaFileContents string, rules []linter.LintRuleFun
 
 	allErrors := linter.LintErrorsWithMetadata{}
 	for _, rule := range rules {
-		errorsFromLintRule := rule(schema)
+		errorsFromLintRule := rule.RuleFunction(schema)
+		for i := range errorsFromLintRule {
+			if len(errorsFromLintRule[i].Severity) == 0 {
+				errorsFromLintRule[i].Severity = rule.Severity
+			}
+		}
 		allErrors = append(allErrors, errorsFromLintRule...)
 	}
 
 	sortedErrors := allErrors.GetSortedErrors()
+	if isIntrospection {
+		// introspection results don't have comments for inline lint config
+		return sortedErrors
+	}
 	inlineLintConfigs := extractInlineLintConfiguration(source)
 	filteredErrors := filterErrors(sortedErrors, inlineLintConfigs)
 	return filteredErrors
 }
 
+// ruleMetadata wraps rule functions into rules with error severity
+func ruleMetadata(rules []linter.LintRuleFunc) []linter.LintRuleMetadata {
+	rulesWithSeverity := make([]linter.LintRuleMetadata, 0, len(rules))
+	for _, rule := range rules {
+		rulesWithSeverity = append(rulesWithSeverity, linter.LintRuleMetadata{RuleFunction: rule, Severity: linter.SeverityError})
+	}
+	return rulesWithSeverity
+}
+
 func filterErrors(errors []linter.LintErrorWithMetadata, configs []InlineLintConfig) []linter.LintErrorWithMetadata {
 	filteredErrors := make([]linter.LintErrorWithMetadata, 0)
 	for _, lintErr := range errors {
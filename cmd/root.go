@@ -10,7 +10,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func newRootCmd() *cobra.Command {
+// NewRootCmd creates the gql command with all its subcommands. Custom binaries can use it to add their own commands,
+// lint rules registered with linter.RegisterRule before calling it are available to the lint command.
+func NewRootCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "gql",
 		Short: "gql is a CLI built for federated GraphQL services' schemas",
@@ -24,9 +26,10 @@ func newRootCmd() *cobra.Command {
 	return cmd
 }
 
-// Execute is a wrapper to execute Run function in subcommands
+// Execute is a wrapper to execute Run function in subcommands. It is the entrypoint for custom binaries which include
+// the stock lint rules and their own ones registered with linter.RegisterRule.
 func Execute() {
-	if err := newRootCmd().Execute(); err != nil {
+	if err := NewRootCmd().Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
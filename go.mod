@@ -5,6 +5,7 @@ go 1.18
 require (
 	github.com/spf13/cobra v1.7.0
 	github.com/vektah/gqlparser/v2 v2.5.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,116 @@
+package compare
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AcceptedChange is an entry of the accepted changes file, a change made intentionally which is matched by its type and path
+type AcceptedChange struct {
+	// ChangeType is the type of the accepted change e.g. FIELD_REMOVED
+	ChangeType ChangeType `yaml:"changeType" json:"changeType"`
+	// Path is the schema path of the accepted change e.g. Type.field.arg
+	Path string `yaml:"path" json:"path"`
+	// Expires is the date the entry stops accepting the change on, the entry never expires when it is empty
+	Expires string `yaml:"expires,omitempty" json:"expires,omitempty"`
+	// Justification explains why the change is accepted
+	Justification string `yaml:"justification,omitempty" json:"justification,omitempty"`
+
+	expires time.Time
+}
+
+// AcceptedChanges is the content of the accepted changes file
+type AcceptedChanges struct {
+	Accepted []*AcceptedChange `yaml:"accepted" json:"accepted"`
+	// Now returns the current time which expiry dates are compared with, time.Now when it is nil
+	Now func() time.Time `yaml:"-" json:"-"`
+}
+
+// StaleAcceptedChange is an entry of the accepted changes file which no longer accepts any change
+type StaleAcceptedChange struct {
+	*AcceptedChange
+	// Reason tells whether the entry expired or no change matches it
+	Reason string `json:"reason"`
+	// Criticality is the criticality of the change an expired entry no longer accepts, nil when no change matches the entry
+	Criticality *Criticality `json:"criticality,omitempty"`
+}
+
+// ParseAcceptedChanges parses the accepted changes file, in yaml or json
+func ParseAcceptedChanges(content []byte) (*AcceptedChanges, error) {
+	accepted := &AcceptedChanges{}
+	if err := yaml.Unmarshal(content, accepted); err != nil {
+		return nil, fmt.Errorf("failed to parse accepted changes, error:%v", err)
+	}
+	for i, entry := range accepted.Accepted {
+		if entry == nil || len(entry.ChangeType) == 0 || len(entry.Path) == 0 {
+			return nil, fmt.Errorf("accepted change[%d] expects both changeType and path", i)
+		}
+		if len(entry.Expires) == 0 {
+			continue
+		}
+		expires, ok := parseRemovalDate(entry.Expires)
+		if !ok {
+			return nil, fmt.Errorf("accepted change[%d] has invalid expiry date:%s, expected YYYY-MM-DD", i, entry.Expires)
+		}
+		entry.expires = expires
+	}
+	return accepted, nil
+}
+
+// AcceptChanges marks the changes matching an entry of the accepted changes as Accepted, so they don't fail the compare.
+// The criticality the change had before is kept, see Change.GetAcceptedFrom.
+// Returns the stale entries, which either expired or no longer match any change.
+func AcceptChanges(changes []*Change, accepted *AcceptedChanges) []*StaleAcceptedChange {
+	if accepted == nil {
+		return nil
+	}
+	now := time.Now()
+	if accepted.Now != nil {
+		now = accepted.Now()
+	}
+	var stale []*StaleAcceptedChange
+	for _, entry := range accepted.Accepted {
+		if !entry.expires.IsZero() && now.After(entry.expires) {
+			expired := &StaleAcceptedChange{AcceptedChange: entry, Reason: fmt.Sprintf("expired on %s", entry.Expires)}
+			// the changes the entry accepted are reported with their own criticality again, the most severe one is kept
+			for _, change := range changes {
+				if change.changeType == entry.ChangeType && change.path == entry.Path &&
+					(expired.Criticality == nil || change.criticalityLevel.severity() > expired.Criticality.severity()) {
+					criticality := change.criticalityLevel
+					expired.Criticality = &criticality
+				}
+			}
+			stale = append(stale, expired)
+			continue
+		}
+		matched := false
+		for _, change := range changes {
+			if change.changeType != entry.ChangeType || change.path != entry.Path {
+				continue
+			}
+			if change.criticalityLevel != Accepted {
+				change.acceptedFrom = change.criticalityLevel
+			}
+			change.criticalityLevel = Accepted
+			change.accepted = entry
+			matched = true
+		}
+		if !matched {
+			stale = append(stale, &StaleAcceptedChange{AcceptedChange: entry, Reason: "no change matches it"})
+		}
+	}
+	return stale
+}
+
+// acceptedText describes the acceptance of the change with the criticality it had and the justification of the entry
+func acceptedText(c *Change) string {
+	if c.accepted == nil {
+		return ""
+	}
+	if len(c.accepted.Justification) == 0 {
+		return c.acceptedFrom.String()
+	}
+	return fmt.Sprintf("%s, %s", c.acceptedFrom, c.accepted.Justification)
+}
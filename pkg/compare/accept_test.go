@@ -0,0 +1,202 @@
+package compare
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestAcceptChanges(t *testing.T) {
+	oldSchema, err := parser.ParseSchema(&ast.Source{Input: `
+	type Book {
+		isbn: String!
+		year: Int
+		title: String
+	}
+	`})
+	if err != nil {
+		t.Fatalf("error parsing old schema, error = %v", err)
+	}
+	newSchema, err := parser.ParseSchema(&ast.Source{Input: `
+	type Book {
+		isbn: String!
+	}
+	`})
+	if err != nil {
+		t.Fatalf("error parsing new schema, error = %v", err)
+	}
+	now := func() time.Time {
+		return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name         string
+		content      string
+		wantAccepted []string
+		wantStale    []string
+	}{
+		{
+			name: "matching change accepted",
+			content: `
+accepted:
+  - changeType: FIELD_REMOVED
+    path: Book.year
+    justification: no client reads the year
+`,
+			wantAccepted: []string{"Book.year"},
+		},
+		{
+			name: "entry accepts the change until its expiry date",
+			content: `
+accepted:
+  - changeType: FIELD_REMOVED
+    path: Book.year
+    expires: 2026-06-01
+`,
+			wantAccepted: []string{"Book.year"},
+		},
+		{
+			name: "expired entry is stale",
+			content: `
+accepted:
+  - changeType: FIELD_REMOVED
+    path: Book.year
+    expires: 2026-01-01
+`,
+			wantStale: []string{"Book.year"},
+		},
+		{
+			name: "entry matching no change is stale",
+			content: `
+accepted:
+  - changeType: FIELD_REMOVED
+    path: Book.title
+  - changeType: FIELD_REMOVED
+    path: Book.author
+  - changeType: FIELD_TYPE_CHANGED
+    path: Book.year
+`,
+			wantAccepted: []string{"Book.title"},
+			wantStale:    []string{"Book.author", "Book.year"},
+		},
+		{
+			name:         "json accepted changes",
+			content:      `{"accepted": [{"changeType": "FIELD_REMOVED", "path": "Book.year"}]}`,
+			wantAccepted: []string{"Book.year"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			accepted, err := ParseAcceptedChanges([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseAcceptedChanges() error = %v", err)
+			}
+			accepted.Now = now
+			changes := FindChangesInSchemas(oldSchema, newSchema)
+			stale := AcceptChanges(changes, accepted)
+
+			var gotAccepted []string
+			for _, change := range changes {
+				if change.criticalityLevel == Accepted {
+					gotAccepted = append(gotAccepted, change.path)
+					if change.acceptedFrom != Breaking {
+						t.Errorf("accepted criticality of %s = %v, want Breaking", change.path, change.acceptedFrom)
+					}
+				} else if change.criticalityLevel != Breaking {
+					t.Errorf("criticality of %s = %v, want Breaking", change.path, change.criticalityLevel)
+				}
+			}
+			if !reflect.DeepEqual(gotAccepted, tt.wantAccepted) {
+				t.Errorf("accepted changes = %v, want %v", gotAccepted, tt.wantAccepted)
+			}
+			var gotStale []string
+			for _, s := range stale {
+				gotStale = append(gotStale, s.Path)
+				// only the expired entry still matches a change, which is reported as Breaking again
+				matches := s.Reason != "no change matches it"
+				if matches != (s.Criticality != nil) || (matches && *s.Criticality != Breaking) {
+					t.Errorf("criticality of stale entry %s = %v, reason %s", s.Path, s.Criticality, s.Reason)
+				}
+			}
+			if !reflect.DeepEqual(gotStale, tt.wantStale) {
+				t.Errorf("stale entries = %v, want %v", gotStale, tt.wantStale)
+			}
+		})
+	}
+}
+
+func TestParseAcceptedChangesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing path", content: "accepted:\n  - changeType: FIELD_REMOVED\n"},
+		{name: "missing change type", content: "accepted:\n  - path: Book.year\n"},
+		{name: "invalid expiry date", content: "accepted:\n  - changeType: FIELD_REMOVED\n    path: Book.year\n    expires: next year\n"},
+		{name: "invalid yaml", content: "accepted: [\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseAcceptedChanges([]byte(tt.content)); err == nil {
+				t.Errorf("ParseAcceptedChanges() expected an error")
+			}
+		})
+	}
+}
+
+func TestWriteJSONReportWithAcceptedChanges(t *testing.T) {
+	oldSchema, err := parser.ParseSchema(&ast.Source{Name: "old.graphql", Input: `type Book { isbn: String! year: Int }`})
+	if err != nil {
+		t.Fatalf("error parsing old schema, error = %v", err)
+	}
+	newSchema, err := parser.ParseSchema(&ast.Source{Name: "new.graphql", Input: `type Book { isbn: String! }`})
+	if err != nil {
+		t.Fatalf("error parsing new schema, error = %v", err)
+	}
+	accepted, err := ParseAcceptedChanges([]byte(`
+accepted:
+  - changeType: FIELD_REMOVED
+    path: Book.year
+    justification: coordinated with the clients
+  - changeType: TYPE_REMOVED
+    path: Author
+`))
+	if err != nil {
+		t.Fatalf("ParseAcceptedChanges() error = %v", err)
+	}
+	changes := FindChangesInSchemas(oldSchema, newSchema)
+	stale := AcceptChanges(changes, accepted)
+
+	var buf bytes.Buffer
+	if err := WriteJSONReport(&buf, changes, stale...); err != nil {
+		t.Fatalf("WriteJSONReport() error = %v", err)
+	}
+	var report struct {
+		Changes []struct {
+			Criticality  string `json:"criticality"`
+			AcceptedFrom string `json:"acceptedFrom"`
+			Accepted     struct {
+				Justification string `json:"justification"`
+			} `json:"accepted"`
+		} `json:"changes"`
+		Stale []struct {
+			ChangeType string `json:"changeType"`
+			Path       string `json:"path"`
+			Reason     string `json:"reason"`
+		} `json:"staleAccepted"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid json report, error = %v", err)
+	}
+	if len(report.Changes) != 1 || report.Changes[0].Criticality != "Accepted" || report.Changes[0].AcceptedFrom != "Breaking" || report.Changes[0].Accepted.Justification != "coordinated with the clients" {
+		t.Errorf("accepted changes = %+v", report.Changes)
+	}
+	if len(report.Stale) != 1 || report.Stale[0].ChangeType != "TYPE_REMOVED" || report.Stale[0].Path != "Author" || len(report.Stale[0].Reason) == 0 {
+		t.Errorf("stale accepted changes = %+v", report.Stale)
+	}
+}
@@ -3,6 +3,7 @@ package compare
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
 )
@@ -115,6 +116,62 @@ const (
 	UnionMemberRemoved ChangeType = "UNION_MEMBER_REMOVED"
 	// UnionMemberAdded Union Member Added
 	UnionMemberAdded ChangeType = "UNION_MEMBER_ADDED"
+	// FieldRenamed Field Renamed
+	FieldRenamed ChangeType = "FIELD_RENAMED"
+	// TypeRenamed Type Renamed
+	TypeRenamed ChangeType = "TYPE_RENAMED"
+	// EnumValueRenamed Enum Value Renamed
+	EnumValueRenamed ChangeType = "ENUM_VALUE_RENAMED"
+	// ArgumentRenamed Argument Renamed
+	ArgumentRenamed ChangeType = "ARGUMENT_RENAMED"
+	// EntityRemoved Entity Removed, the type has no @key anymore
+	EntityRemoved ChangeType = "ENTITY_REMOVED"
+	// EntityKeyAdded Entity Key Added
+	EntityKeyAdded ChangeType = "ENTITY_KEY_ADDED"
+	// EntityKeyRemoved Entity Key Removed
+	EntityKeyRemoved ChangeType = "ENTITY_KEY_REMOVED"
+	// EntityKeyChanged Entity Key Changed
+	EntityKeyChanged ChangeType = "ENTITY_KEY_CHANGED"
+	// FieldExternalAdded Field External Added
+	FieldExternalAdded ChangeType = "FIELD_EXTERNAL_ADDED"
+	// FieldExternalRemoved Field External Removed
+	FieldExternalRemoved ChangeType = "FIELD_EXTERNAL_REMOVED"
+	// FieldRequiresAdded Field Requires Added
+	FieldRequiresAdded ChangeType = "FIELD_REQUIRES_ADDED"
+	// FieldRequiresRemoved Field Requires Removed
+	FieldRequiresRemoved ChangeType = "FIELD_REQUIRES_REMOVED"
+	// FieldRequiresChanged Field Requires Changed
+	FieldRequiresChanged ChangeType = "FIELD_REQUIRES_CHANGED"
+	// FieldProvidesAdded Field Provides Added
+	FieldProvidesAdded ChangeType = "FIELD_PROVIDES_ADDED"
+	// FieldProvidesRemoved Field Provides Removed
+	FieldProvidesRemoved ChangeType = "FIELD_PROVIDES_REMOVED"
+	// FieldProvidesChanged Field Provides Changed
+	FieldProvidesChanged ChangeType = "FIELD_PROVIDES_CHANGED"
+	// ShareableAdded Shareable Added
+	ShareableAdded ChangeType = "SHAREABLE_ADDED"
+	// ShareableRemoved Shareable Removed
+	ShareableRemoved ChangeType = "SHAREABLE_REMOVED"
+	// FieldOverrideAdded Field Override Added
+	FieldOverrideAdded ChangeType = "FIELD_OVERRIDE_ADDED"
+	// FieldOverrideRemoved Field Override Removed
+	FieldOverrideRemoved ChangeType = "FIELD_OVERRIDE_REMOVED"
+	// FieldOverrideChanged Field Override Changed
+	FieldOverrideChanged ChangeType = "FIELD_OVERRIDE_CHANGED"
+	// ScalarSpecifiedByAdded Scalar Specified By Added
+	ScalarSpecifiedByAdded ChangeType = "SCALAR_SPECIFIED_BY_ADDED"
+	// ScalarSpecifiedByRemoved Scalar Specified By Removed
+	ScalarSpecifiedByRemoved ChangeType = "SCALAR_SPECIFIED_BY_REMOVED"
+	// ScalarSpecifiedByChanged Scalar Specified By Changed
+	ScalarSpecifiedByChanged ChangeType = "SCALAR_SPECIFIED_BY_CHANGED"
+	// InterfaceTypeInterfaceAdded Interface Type Interface Added
+	InterfaceTypeInterfaceAdded ChangeType = "INTERFACE_TYPE_INTERFACE_ADDED"
+	// InterfaceTypeInterfaceRemoved Interface Type Interface Removed
+	InterfaceTypeInterfaceRemoved ChangeType = "INTERFACE_TYPE_INTERFACE_REMOVED"
+	// PossibleTypeAdded Possible Type Added
+	PossibleTypeAdded ChangeType = "POSSIBLE_TYPE_ADDED"
+	// PossibleTypeRemoved Possible Type Removed
+	PossibleTypeRemoved ChangeType = "POSSIBLE_TYPE_REMOVED"
 )
 
 // Criticality severity of a change in schema
@@ -127,10 +184,19 @@ const (
 	Dangerous Criticality = 1
 	// Breaking Change is incompatible with previous version
 	Breaking Criticality = 2
+	// SafeUnused Change is incompatible with previous version but none of the client operations use what it changes
+	SafeUnused Criticality = 3
+	// Accepted Change is listed in the accepted changes file, it was made intentionally and coordinated with the clients
+	Accepted Criticality = 4
 )
 
 const deprecatedDirective = "deprecated"
 
+const specifiedByDirective = "specifiedBy"
+
+// schemaPath is the path of the changes in the schema definition
+const schemaPath = "schema"
+
 // Change defines a change in schema
 type Change struct {
 	message          string
@@ -138,6 +204,18 @@ type Change struct {
 	criticalityLevel Criticality
 	path             string
 	position         *ast.Position
+	operations       []string
+	accepted         *AcceptedChange
+	// acceptedFrom is the criticality the change had before it was accepted, only set when the change is Accepted
+	acceptedFrom Criticality
+	// oldPosition is the position of the changed element in the old schema, nil when it was added
+	oldPosition *ast.Position
+	// newPosition is the position of the changed element in the new schema, nil when it was removed
+	newPosition *ast.Position
+	// directive is the name of the directive the change is about, empty for changes which aren't about a directive
+	directive string
+	// diff is the difference between the old and new value of value changes
+	diff *ValueDiff
 }
 
 // GetPosition get change position
@@ -145,6 +223,16 @@ func (c *Change) GetPosition() *ast.Position {
 	return c.position
 }
 
+// GetOldPosition get the position of the changed element in the old schema, nil when it was added
+func (c *Change) GetOldPosition() *ast.Position {
+	return c.oldPosition
+}
+
+// GetNewPosition get the position of the changed element in the new schema, nil when it was removed
+func (c *Change) GetNewPosition() *ast.Position {
+	return c.newPosition
+}
+
 // GetMessage get change message
 func (c *Change) GetMessage() string {
 	return c.message
@@ -165,6 +253,26 @@ func (c *Change) GetPath() string {
 	return c.path
 }
 
+// GetOperations get the client operations broken by the change, only set when comparing with operations
+func (c *Change) GetOperations() []string {
+	return c.operations
+}
+
+// GetValueDiff get the difference between the old and new value, only set for default value and directive argument value changes
+func (c *Change) GetValueDiff() *ValueDiff {
+	return c.diff
+}
+
+// GetAccepted get the accepted changes entry matching the change, only set when the change is Accepted
+func (c *Change) GetAccepted() *AcceptedChange {
+	return c.accepted
+}
+
+// GetAcceptedFrom get the criticality the change had before it was accepted, only set when the change is Accepted
+func (c *Change) GetAcceptedFrom() Criticality {
+	return c.acceptedFrom
+}
+
 // string get change criticality level string
 func (c Criticality) String() string {
 	switch c {
@@ -174,19 +282,105 @@ func (c Criticality) String() string {
 		return "Dangerous"
 	case NonBreaking:
 		return "NonBreaking"
+	case SafeUnused:
+		return "SafeUnused"
+	case Accepted:
+		return "Accepted"
 	default:
 		return ""
 	}
 }
 
+// ParseCriticality returns the criticality level for its name, the name is matched case-insensitively
+func ParseCriticality(name string) (Criticality, error) {
+	for _, c := range []Criticality{Breaking, SafeUnused, Dangerous, NonBreaking, Accepted} {
+		if strings.EqualFold(name, c.String()) {
+			return c, nil
+		}
+	}
+	return NonBreaking, fmt.Errorf("invalid criticality[%s], expected one of: Breaking, SafeUnused, Dangerous, NonBreaking, Accepted", name)
+}
+
+// severity orders the criticalities from the least to the most severe, Accepted and SafeUnused changes are less severe than Dangerous ones
+func (c Criticality) severity() int {
+	switch c {
+	case Accepted:
+		return 1
+	case SafeUnused:
+		return 2
+	case Dangerous:
+		return 3
+	case Breaking:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// criticalitiesBySeverity returns all the criticalities from the most to the least severe, the order changes are reported in
+func criticalitiesBySeverity() []Criticality {
+	criticalities := []Criticality{NonBreaking, Dangerous, Breaking, SafeUnused, Accepted}
+	sort.Slice(criticalities, func(i, j int) bool {
+		return criticalities[i].severity() > criticalities[j].severity()
+	})
+	return criticalities
+}
+
+// Option configures how schemas are compared
+type Option func(*options)
+
+type options struct {
+	operations         *Operations
+	federation         FederationVersion
+	separateExtensions bool
+	renameThreshold    float64
+	deprecationPolicy  *DeprecationPolicy
+	overrides          []CriticalityOverride
+}
+
+// WithOperations checks the breaking changes against the client operations, breaking changes none of the operations
+// use are downgraded to SafeUnused and the others list the operations they break
+func WithOperations(operations *Operations) Option {
+	return func(o *options) {
+		o.operations = operations
+	}
+}
+
 // FindChangesInSchemas compares two schemas, returns the list of all changes made in the second schema
-func FindChangesInSchemas(oldSchema *ast.SchemaDocument, newSchema *ast.SchemaDocument) []*Change {
+func FindChangesInSchemas(oldSchema *ast.SchemaDocument, newSchema *ast.SchemaDocument, opts ...Option) []*Change {
+	compareOptions := &options{}
+	for _, opt := range opts {
+		opt(compareOptions)
+	}
 	var changes []*Change
 	changes = []*Change{}
+	if !compareOptions.separateExtensions {
+		oldSchema = mergeExtensions(oldSchema)
+		newSchema = mergeExtensions(newSchema)
+	}
+	if len(compareOptions.federation) != 0 {
+		// federation directives are compared by the federation rules instead of as plain directive usages
+		changes = append(changes, changeInFederation(oldSchema, newSchema, compareOptions.federation)...)
+		directives := federationDirectives(compareOptions.federation)
+		oldSchema = withoutDirectives(oldSchema, directives)
+		newSchema = withoutDirectives(newSchema, directives)
+	}
 	changes = append(changes, changeInSchema(oldSchema.Schema, newSchema.Schema)...)
 	changes = append(changes, changeInSchema(oldSchema.SchemaExtension, newSchema.SchemaExtension)...)
 	changes = append(changes, changeInTypes(oldSchema, newSchema)...)
+	changes = append(changes, changeInPossibleTypes(oldSchema, newSchema)...)
 	changes = append(changes, changeInDirective(oldSchema.Directives, newSchema.Directives)...)
+	applyTypeUsage(changes, oldSchema, newSchema)
+	if compareOptions.renameThreshold > 0 {
+		changes = detectRenames(changes, oldSchema, newSchema, compareOptions.renameThreshold)
+	}
+	if compareOptions.operations != nil {
+		applyOperationUsage(changes, oldSchema, compareOptions.operations)
+	}
+	if compareOptions.deprecationPolicy != nil {
+		applyDeprecationPolicy(changes, oldSchema, compareOptions.deprecationPolicy)
+	}
+	applyCriticalityOverrides(changes, compareOptions.overrides)
 	return changes
 }
 
@@ -196,6 +390,7 @@ func changeInSchema(oldSchemaDefs ast.SchemaDefinitionList, newSchemaDefs ast.Sc
 	if len(oldSchemaDefs) == 0 && len(newSchemaDefs) == 0 {
 		return changes
 	}
+	changes = append(changes, changeInSchemaDirectives(oldSchemaDefs, newSchemaDefs)...)
 	if len(oldSchemaDefs) > 0 && len(newSchemaDefs) == 0 {
 		oldQuery := getOperationForName(oldSchemaDefs[0].OperationTypes, ast.Query)
 		changes = append(changes, changesInSchemaOperation(oldQuery, nil, ast.Query)...)
@@ -234,6 +429,22 @@ func changeInSchema(oldSchemaDefs ast.SchemaDefinitionList, newSchemaDefs ast.Sc
 	return changes
 }
 
+// changeInSchemaDirectives change in the directives of the schema definitions
+func changeInSchemaDirectives(oldSchemaDefs ast.SchemaDefinitionList, newSchemaDefs ast.SchemaDefinitionList) []*Change {
+	var oDirs, nDirs ast.DirectiveList
+	for _, schemaDef := range oldSchemaDefs {
+		oDirs = append(oDirs, schemaDef.Directives...)
+	}
+	for _, schemaDef := range newSchemaDefs {
+		nDirs = append(nDirs, schemaDef.Directives...)
+	}
+	pos := oldSchemaDefs
+	if len(newSchemaDefs) > 0 {
+		pos = newSchemaDefs
+	}
+	return changeInTypeFieldDirectives(oDirs, nDirs, schemaPath, pos[0].Position)
+}
+
 func changesInSchemaOperation(oldOp *ast.OperationTypeDefinition, newOp *ast.OperationTypeDefinition, op ast.Operation) []*Change {
 	var changes []*Change
 	var changeType ChangeType
@@ -248,25 +459,29 @@ func changesInSchemaOperation(oldOp *ast.OperationTypeDefinition, newOp *ast.Ope
 	if oldOp == nil && newOp != nil {
 		changes = append(changes, &Change{
 			changeType:       changeType,
-			criticalityLevel: NonBreaking,
+			criticalityLevel: defaultCriticalityIn(changeType, rootAddedContext),
 			message:          fmt.Sprintf("Schema %s root has added '%s'", op, newOp.Operation),
 			position:         newOp.Position,
+			newPosition:      newOp.Position,
 		})
 	}
 	if oldOp != nil && newOp == nil {
 		changes = append(changes, &Change{
 			changeType:       changeType,
-			criticalityLevel: Breaking,
+			criticalityLevel: defaultCriticalityIn(changeType, rootRemovedContext),
 			message:          fmt.Sprintf("Schema %s root has removed '%s'", op, oldOp.Operation),
 			position:         oldOp.Position,
+			oldPosition:      oldOp.Position,
 		})
 	}
 	if oldOp != nil && newOp != nil && oldOp.Type != newOp.Type {
 		changes = append(changes, &Change{
 			changeType:       changeType,
-			criticalityLevel: Breaking,
+			criticalityLevel: defaultCriticalityIn(changeType, rootChangedContext),
 			message:          fmt.Sprintf("Schema %s root has changed from '%s' to '%s'", op, oldOp.Operation, newOp.Operation),
 			position:         newOp.Position,
+			oldPosition:      oldOp.Position,
+			newPosition:      newOp.Position,
 		})
 	}
 	return changes
@@ -289,12 +504,19 @@ func changeInTypes(oldSchema *ast.SchemaDocument, newSchema *ast.SchemaDocument)
 		ot := defs[0]
 		nt := defs[1]
 		if ot.Kind == ast.Enum && nt.Kind == ast.Enum {
+			changes = append(changes, changeInTypeFieldDirectives(ot.Directives, nt.Directives, nt.Name, nt.Position)...)
 			changes = append(changes, changeInEnum(ot, nt)...)
 		}
 		if ot.Kind == ast.InputObject && nt.Kind == ast.InputObject {
+			changes = append(changes, changeInTypeFieldDirectives(ot.Directives, nt.Directives, nt.Name, nt.Position)...)
 			changes = append(changes, changeInInputFields(ot, nt)...)
 		}
+		if ot.Kind == ast.Scalar && nt.Kind == ast.Scalar {
+			changes = append(changes, changeInScalar(ot, nt)...)
+		}
 		if ot.Kind == ast.Interface && nt.Kind == ast.Interface {
+			changes = append(changes, checkTypeInterfaceRemoved(ot, nt)...)
+			changes = append(changes, checkTypeInterfacesAdded(ot, nt)...)
 			changes = append(changes, changeInTypeFieldDirectives(ot.Directives, nt.Directives, nt.Name, nt.Position)...)
 			changes = append(changes, changeInFields(ot, nt)...)
 		}
@@ -309,19 +531,23 @@ func changeInTypes(oldSchema *ast.SchemaDocument, newSchema *ast.SchemaDocument)
 			//For example, turning an object type to a scalar type would break queries that define a selection set for this type.
 			changes = append(changes, &Change{
 				changeType:       TypeKindChanged,
-				criticalityLevel: Breaking,
+				criticalityLevel: defaultCriticality(TypeKindChanged),
 				message:          fmt.Sprintf("Type '%s' kind changed from '%s' to '%s'", ot.Name, ot.Kind, nt.Kind),
 				path:             nt.Name,
 				position:         nt.Position,
+				oldPosition:      ot.Position,
+				newPosition:      nt.Position,
 			})
 		}
 		if ot.Description != nt.Description {
 			changes = append(changes, &Change{
 				changeType:       TypeDescriptionChanged,
-				criticalityLevel: NonBreaking,
+				criticalityLevel: defaultCriticality(TypeDescriptionChanged),
 				message:          fmt.Sprintf("Type '%s' description changed", ot.Name),
 				path:             nt.Name,
 				position:         nt.Position,
+				oldPosition:      ot.Position,
+				newPosition:      nt.Position,
 			})
 		}
 	}
@@ -340,10 +566,11 @@ func checkTypeRemoved(oldSchemaDefs ast.DefinitionList, newSchemaDefs ast.Defini
 			//removing a type from schema is a breaking change
 			changes = append(changes, &Change{
 				changeType:       TypeRemoved,
-				criticalityLevel: Breaking,
+				criticalityLevel: defaultCriticality(TypeRemoved),
 				message:          msg,
 				path:             ot.Name,
 				position:         ot.Position,
+				oldPosition:      ot.Position,
 			})
 		} else {
 			persistedType[ot.Name] = []*ast.Definition{ot, nt}
@@ -364,10 +591,11 @@ func checkTypeAdded(oldSchemaDefs ast.DefinitionList, newSchemaDefs ast.Definiti
 			//type added to new schema
 			changes = append(changes, &Change{
 				changeType:       TypeAdded,
-				criticalityLevel: NonBreaking,
+				criticalityLevel: defaultCriticality(TypeAdded),
 				message:          msg,
 				path:             nt.Name,
 				position:         nt.Position,
+				newPosition:      nt.Position,
 			})
 		}
 	}
@@ -382,20 +610,25 @@ func changeInDirective(oDirs ast.DirectiveDefinitionList, nDirs ast.DirectiveDef
 		if nd == nil {
 			changes = append(changes, &Change{
 				changeType:       DirectiveRemoved,
-				criticalityLevel: Breaking,
+				directive:        od.Name,
+				criticalityLevel: defaultCriticalityIn(DirectiveRemoved, directiveDefinitionContext),
 				message:          fmt.Sprintf("Directive '@%s' was removed ", od.Name),
 				path:             fmt.Sprintf("@%s", od.Name),
 				position:         od.Position,
+				oldPosition:      od.Position,
 			})
 		} else {
 			//description changed
 			if od.Description != nd.Description {
 				changes = append(changes, &Change{
 					changeType:       DirectiveDescriptionChanged,
-					criticalityLevel: NonBreaking,
+					directive:        od.Name,
+					criticalityLevel: defaultCriticality(DirectiveDescriptionChanged),
 					message:          fmt.Sprintf("Directive '@%s' description changed ", od.Name),
 					path:             fmt.Sprintf("@%s", nd.Name),
 					position:         nd.Position,
+					oldPosition:      od.Position,
+					newPosition:      nd.Position,
 				})
 			}
 			changes = append(changes, checkDirectiveLocationChanged(od, nd)...)
@@ -419,18 +652,22 @@ func checkDirectiveArgumentAdded(od *ast.DirectiveDefinition, nd *ast.DirectiveD
 			if nArg.Type.NonNull {
 				changes = append(changes, &Change{
 					changeType:       DirectiveArgumentAdded,
-					criticalityLevel: Breaking,
+					directive:        nd.Name,
+					criticalityLevel: defaultCriticalityIn(DirectiveArgumentAdded, requiredContext),
 					message:          fmt.Sprintf("Non-nullable argument '%s:%s' was added to directive '@%s'", nArg.Name, nArg.Type.String(), nd.Name),
 					path:             fmt.Sprintf("@%s.%s", od.Name, nArg.Name),
 					position:         nArg.Position,
+					newPosition:      nArg.Position,
 				})
 			} else {
 				changes = append(changes, &Change{
 					changeType:       DirectiveArgumentAdded,
-					criticalityLevel: NonBreaking,
+					directive:        nd.Name,
+					criticalityLevel: defaultCriticalityIn(DirectiveArgumentAdded, optionalContext),
 					message:          fmt.Sprintf("Argument '%s:%s' was added to to directive '@%s'", nArg.Name, nArg.Type.String(), nd.Name),
 					path:             fmt.Sprintf("@%s.%s", od.Name, nArg.Name),
 					position:         nArg.Position,
+					newPosition:      nArg.Position,
 				})
 			}
 		}
@@ -446,10 +683,12 @@ func changeInDirectiveArguments(od *ast.DirectiveDefinition, nd *ast.DirectiveDe
 			//argument is removed
 			changes = append(changes, &Change{
 				changeType:       DirectiveArgumentRemoved,
-				criticalityLevel: Breaking,
+				directive:        od.Name,
+				criticalityLevel: defaultCriticalityIn(DirectiveArgumentRemoved, directiveDefinitionContext),
 				message:          fmt.Sprintf("Argument '%s' was removed from directive '@%s'", oArg.Name, od.Name),
 				path:             fmt.Sprintf("@%s.%s", od.Name, oArg.Name),
 				position:         nd.Position,
+				oldPosition:      oArg.Position,
 			})
 		} else {
 			//check argument type change
@@ -459,12 +698,16 @@ func changeInDirectiveArguments(od *ast.DirectiveDefinition, nd *ast.DirectiveDe
 			if oArg.Description != nArg.Description {
 				changes = append(changes, &Change{
 					changeType:       DirectiveArgumentDescriptionChanged,
-					criticalityLevel: NonBreaking,
+					directive:        od.Name,
+					criticalityLevel: defaultCriticality(DirectiveArgumentDescriptionChanged),
 					message:          fmt.Sprintf("Argument '%s' description changed in directive '@%s' ", oArg.Name, od.Name),
 					path:             fmt.Sprintf("@%s.%s", od.Name, oArg.Name),
 					position:         nArg.Position,
+					oldPosition:      oArg.Position,
+					newPosition:      nArg.Position,
 				})
 			}
+			changes = append(changes, changeInTypeFieldDirectives(oArg.Directives, nArg.Directives, fmt.Sprintf("@%s.%s", od.Name, oArg.Name), nArg.Position)...)
 		}
 	}
 	return changes
@@ -474,26 +717,33 @@ func checkDirectiveArgumentTypeChanged(od *ast.DirectiveDefinition, oArg *ast.Ar
 	var changes []*Change
 	if oArg.Type.String() != nArg.Type.String() {
 		//Changing an input field from non-null to null is considered non-breaking.
-		cl := NonBreaking
+		typeContext := compatibleTypeContext
 		if !isSafeChangeForInputValue(oArg.Type, nArg.Type) {
-			cl = Breaking
+			typeContext = incompatibleTypeContext
 		}
 		changes = append(changes, &Change{
 			changeType:       DirectiveArgumentTypeChanged,
-			criticalityLevel: cl,
+			directive:        od.Name,
+			criticalityLevel: defaultCriticalityIn(DirectiveArgumentTypeChanged, typeContext),
 			message:          fmt.Sprintf("Argument '%s' type changed from '%s' to '%s' in directive '@%s' ", oArg.Name, oArg.Type.String(), nArg.Type.String(), od.Name),
 			path:             fmt.Sprintf("@%s.%s", od.Name, oArg.Name),
 			position:         nArg.Position,
+			oldPosition:      oArg.Position,
+			newPosition:      nArg.Position,
 		})
 	}
 	//Changing the default value for an argument may change the runtime behaviour of a field if it was never provided.
-	if oArg.DefaultValue.String() != nArg.DefaultValue.String() {
+	if diff := newValueDiff(oArg.DefaultValue, nArg.DefaultValue); diff != nil {
 		changes = append(changes, &Change{
 			changeType:       DirectiveArgumentDefaultValueChanged,
-			criticalityLevel: Dangerous,
+			diff:             diff,
+			directive:        od.Name,
+			criticalityLevel: defaultCriticality(DirectiveArgumentDefaultValueChanged),
 			message:          fmt.Sprintf("Argument '%s' default value changed from '%s' to '%s' in directive '@%s' ", oArg.Name, oArg.DefaultValue.String(), nArg.DefaultValue.String(), od.Name),
 			path:             fmt.Sprintf("@%s.%s", od.Name, oArg.Name),
 			position:         nArg.Position,
+			oldPosition:      oArg.Position,
+			newPosition:      nArg.Position,
 		})
 	}
 	return changes
@@ -505,20 +755,26 @@ func checkDirectiveRepeatableChanged(od *ast.DirectiveDefinition, nd *ast.Direct
 	if od.IsRepeatable && !nd.IsRepeatable {
 		changes = append(changes, &Change{
 			changeType:       DirectiveRepeatableRemoved,
-			criticalityLevel: Breaking,
+			directive:        od.Name,
+			criticalityLevel: defaultCriticality(DirectiveRepeatableRemoved),
 			message:          fmt.Sprintf("Repeatable flag was removed from '@%s' directive", od.Name),
 			path:             fmt.Sprintf("@%s", nd.Name),
 			position:         nd.Position,
+			oldPosition:      od.Position,
+			newPosition:      nd.Position,
 		})
 	}
 	//isRepeatable added
 	if !od.IsRepeatable && nd.IsRepeatable {
 		changes = append(changes, &Change{
 			changeType:       DirectiveRepeatableAdded,
-			criticalityLevel: NonBreaking,
+			directive:        od.Name,
+			criticalityLevel: defaultCriticality(DirectiveRepeatableAdded),
 			message:          fmt.Sprintf("Repeatable flag was removed from '@%s' directive", od.Name),
 			path:             fmt.Sprintf("@%s", nd.Name),
 			position:         nd.Position,
+			oldPosition:      od.Position,
+			newPosition:      nd.Position,
 		})
 	}
 	return changes
@@ -538,10 +794,13 @@ func checkDirectiveLocationChanged(od *ast.DirectiveDefinition, nd *ast.Directiv
 		if !found {
 			changes = append(changes, &Change{
 				changeType:       DirectiveLocationRemoved,
-				criticalityLevel: Breaking,
+				directive:        od.Name,
+				criticalityLevel: defaultCriticality(DirectiveLocationRemoved),
 				message:          fmt.Sprintf("Location '%s' was removed from '@%s' directive", ol, od.Name),
 				path:             fmt.Sprintf("@%s", nd.Name),
 				position:         nd.Position,
+				oldPosition:      od.Position,
+				newPosition:      nd.Position,
 			})
 		}
 	}
@@ -555,10 +814,13 @@ func checkDirectiveLocationChanged(od *ast.DirectiveDefinition, nd *ast.Directiv
 		if !found {
 			changes = append(changes, &Change{
 				changeType:       DirectiveLocationAdded,
-				criticalityLevel: NonBreaking,
+				directive:        od.Name,
+				criticalityLevel: defaultCriticality(DirectiveLocationAdded),
 				message:          fmt.Sprintf("Location '%s' was added to '@%s' directive", nl, nd.Name),
 				path:             fmt.Sprintf("@%s", nd.Name),
 				position:         nd.Position,
+				oldPosition:      od.Position,
+				newPosition:      nd.Position,
 			})
 		}
 	}
@@ -572,10 +834,12 @@ func checkDirectiveAdded(oDirs ast.DirectiveDefinitionList, nDirs ast.DirectiveD
 		if od == nil {
 			changes = append(changes, &Change{
 				changeType:       DirectiveAdded,
-				criticalityLevel: NonBreaking,
+				directive:        nd.Name,
+				criticalityLevel: defaultCriticality(DirectiveAdded),
 				message:          fmt.Sprintf("Directive '@%s' was added ", nd.Name),
 				path:             fmt.Sprintf("@%s", nd.Name),
 				position:         nd.Position,
+				newPosition:      nd.Position,
 			})
 		}
 	}
@@ -595,22 +859,26 @@ func changeInEnum(oDef *ast.Definition, nDef *ast.Definition) []*Change {
 			//Removing an enum value will cause existing queries that use this enum value to error.
 			changes = append(changes, &Change{
 				changeType:       EnumValueRemoved,
-				criticalityLevel: Breaking,
+				criticalityLevel: defaultCriticality(EnumValueRemoved),
 				message:          msg,
 				path:             fmt.Sprintf("%s.%s", oDef.Name, ov.Name),
 				position:         ov.Position,
+				oldPosition:      ov.Position,
 			})
 		} else {
 			if ov.Description != nv.Description {
 				changes = append(changes, &Change{
 					changeType:       EnumValueDescriptionChanged,
-					criticalityLevel: NonBreaking,
+					criticalityLevel: defaultCriticality(EnumValueDescriptionChanged),
 					message:          fmt.Sprintf("Enum value '%s' description changed in  enum '%s' ", ov.Name, oDef.Name),
 					path:             fmt.Sprintf("%s.%s", oDef.Name, ov.Name),
 					position:         nv.Position,
+					oldPosition:      ov.Position,
+					newPosition:      nv.Position,
 				})
 			}
 			changes = append(changes, checkEnumValueDeprecationChanged(oDef, nv, ov)...)
+			changes = append(changes, changeInTypeFieldDirectives(ov.Directives, nv.Directives, fmt.Sprintf("%s.%s", nDef.Name, nv.Name), nv.Position)...)
 		}
 	}
 	changes = append(changes, checkEnumValuesAdded(oDef, nDef)...)
@@ -624,10 +892,12 @@ func checkEnumValueDeprecationChanged(oDef *ast.Definition, nv *ast.EnumValueDef
 	if oDep == nil && nDep != nil {
 		changes = append(changes, &Change{
 			changeType:       EnumValueDeprecationAdded,
-			criticalityLevel: Dangerous,
+			criticalityLevel: defaultCriticality(EnumValueDeprecationAdded),
 			message:          fmt.Sprintf("Enum value '%s' deprecated in enum '%s' ", ov.Name, oDef.Name),
 			path:             fmt.Sprintf("%s.%s", oDef.Name, ov.Name),
 			position:         nv.Position,
+			oldPosition:      ov.Position,
+			newPosition:      nv.Position,
 		})
 	}
 	if oDep != nil && nDep != nil {
@@ -636,10 +906,12 @@ func checkEnumValueDeprecationChanged(oDef *ast.Definition, nv *ast.EnumValueDef
 		if oReason != nil && nReason != nil && oReason.Value.String() != nReason.Value.String() {
 			changes = append(changes, &Change{
 				changeType:       EnumValueDeprecationReasonChanged,
-				criticalityLevel: NonBreaking,
+				criticalityLevel: defaultCriticality(EnumValueDeprecationReasonChanged),
 				message:          fmt.Sprintf("Enum value '%s' deprecation reason changed in enum '%s' ", ov.Name, oDef.Name),
 				path:             fmt.Sprintf("%s.%s", oDef.Name, ov.Name),
 				position:         nv.Position,
+				oldPosition:      ov.Position,
+				newPosition:      nv.Position,
 			})
 		}
 	}
@@ -654,10 +926,11 @@ func checkEnumValuesAdded(oDef *ast.Definition, nDef *ast.Definition) []*Change
 			//Adding an enum value may break existing clients that were not programming defensively against an added case when querying an enum.
 			changes = append(changes, &Change{
 				changeType:       EnumValueAdded,
-				criticalityLevel: Dangerous,
+				criticalityLevel: defaultCriticality(EnumValueAdded),
 				message:          fmt.Sprintf("Enum value '%s' was added to enum '%s'", nv.Name, nDef.Name),
 				path:             fmt.Sprintf("%s.%s", oDef.Name, nv.Name),
 				position:         nv.Position,
+				newPosition:      nv.Position,
 			})
 		}
 	}
@@ -686,12 +959,18 @@ func checkTypeInterfaceRemoved(oDef *ast.Definition, nDef *ast.Definition) []*Ch
 		}
 		if !found {
 			//Removing an interface from an object type can cause existing queries that use this in a fragment spread to error.
+			changeType := ObjectTypeInterfaceRemoved
+			if nDef.Kind == ast.Interface {
+				changeType = InterfaceTypeInterfaceRemoved
+			}
 			changes = append(changes, &Change{
-				changeType:       ObjectTypeInterfaceRemoved,
-				criticalityLevel: Breaking,
-				message:          fmt.Sprintf("'%s' object type no longer implements '%s' interface", oDef.Name, oInt),
+				changeType:       changeType,
+				criticalityLevel: defaultCriticality(changeType),
+				message:          fmt.Sprintf("'%s' %s type no longer implements '%s' interface", oDef.Name, strings.ToLower(string(nDef.Kind)), oInt),
 				path:             oDef.Name,
 				position:         nDef.Position,
+				oldPosition:      oDef.Position,
+				newPosition:      nDef.Position,
 			})
 		}
 	}
@@ -710,20 +989,69 @@ func checkTypeInterfacesAdded(oDef *ast.Definition, nDef *ast.Definition) []*Cha
 		}
 		if !found {
 			//Adding an interface to an object type may break existing clients that were not programming defensively against a new possible type.
+			changeType := ObjectTypeInterfaceAdded
+			if nDef.Kind == ast.Interface {
+				changeType = InterfaceTypeInterfaceAdded
+			}
 			changes = append(changes, &Change{
-				changeType:       ObjectTypeInterfaceAdded,
-				criticalityLevel: Dangerous,
-				message:          fmt.Sprintf("'%s' object type implements '%s' interface", nDef.Name, nInt),
+				changeType:       changeType,
+				criticalityLevel: defaultCriticality(changeType),
+				message:          fmt.Sprintf("'%s' %s type implements '%s' interface", nDef.Name, strings.ToLower(string(nDef.Kind)), nInt),
 				path:             oDef.Name,
 				position:         nDef.Position,
+				oldPosition:      oDef.Position,
+				newPosition:      nDef.Position,
 			})
 		}
 	}
 	return changes
 }
 
+// changeInScalar change in the specifiedBy url and the directives of a scalar
+func changeInScalar(oDef *ast.Definition, nDef *ast.Definition) []*Change {
+	var changes []*Change
+	oSpec := oDef.Directives.ForName(specifiedByDirective)
+	nSpec := nDef.Directives.ForName(specifiedByDirective)
+	switch {
+	case oSpec == nil && nSpec != nil:
+		changes = append(changes, &Change{
+			changeType:       ScalarSpecifiedByAdded,
+			criticalityLevel: defaultCriticality(ScalarSpecifiedByAdded),
+			message:          fmt.Sprintf("Scalar '%s' specification url '%s' was added", nDef.Name, directiveArgument(nSpec, "url")),
+			path:             nDef.Name,
+			position:         nSpec.Position,
+			newPosition:      nSpec.Position,
+		})
+	case oSpec != nil && nSpec == nil:
+		//Clients may rely on the format of the specification to parse the scalar values.
+		changes = append(changes, &Change{
+			changeType:       ScalarSpecifiedByRemoved,
+			criticalityLevel: defaultCriticality(ScalarSpecifiedByRemoved),
+			message:          fmt.Sprintf("Scalar '%s' specification url '%s' was removed", nDef.Name, directiveArgument(oSpec, "url")),
+			path:             nDef.Name,
+			position:         nDef.Position,
+			oldPosition:      oSpec.Position,
+		})
+	case oSpec != nil && nSpec != nil && directiveArgument(oSpec, "url") != directiveArgument(nSpec, "url"):
+		//A new specification changes the format of the scalar values clients send and receive.
+		changes = append(changes, &Change{
+			changeType:       ScalarSpecifiedByChanged,
+			criticalityLevel: defaultCriticality(ScalarSpecifiedByChanged),
+			message:          fmt.Sprintf("Scalar '%s' specification url changed from '%s' to '%s'", nDef.Name, directiveArgument(oSpec, "url"), directiveArgument(nSpec, "url")),
+			path:             nDef.Name,
+			position:         nSpec.Position,
+			oldPosition:      oSpec.Position,
+			newPosition:      nSpec.Position,
+		})
+	}
+	specifiedBy := []string{specifiedByDirective}
+	changes = append(changes, changeInTypeFieldDirectives(directivesWithout(oDef.Directives, specifiedBy), directivesWithout(nDef.Directives, specifiedBy), nDef.Name, nDef.Position)...)
+	return changes
+}
+
 func changeInUnion(oDef *ast.Definition, nDef *ast.Definition) []*Change {
 	var changes []*Change
+	changes = append(changes, changeInTypeFieldDirectives(oDef.Directives, nDef.Directives, nDef.Name, nDef.Position)...)
 	//Check if union types added/removed
 	changes = append(changes, checkUnionMemberRemoved(oDef, nDef)...)
 	changes = append(changes, checkUnionMemberAdded(oDef, nDef)...)
@@ -744,10 +1072,12 @@ func checkUnionMemberRemoved(oDef *ast.Definition, nDef *ast.Definition) []*Chan
 			//Removing a union member from a union can cause existing queries that use this union member in a fragment spread to error.
 			changes = append(changes, &Change{
 				changeType:       UnionMemberRemoved,
-				criticalityLevel: Breaking,
+				criticalityLevel: defaultCriticality(UnionMemberRemoved),
 				message:          fmt.Sprintf("Member '%s' was removed from Union type '%s'", ot, oDef.Name),
 				path:             oDef.Name,
 				position:         nDef.Position,
+				oldPosition:      oDef.Position,
+				newPosition:      nDef.Position,
 			})
 		}
 	}
@@ -768,10 +1098,12 @@ func checkUnionMemberAdded(oDef *ast.Definition, nDef *ast.Definition) []*Change
 			//Adding a possible type to Unions may break existing clients that were not programming defensively against a new possible type.
 			changes = append(changes, &Change{
 				changeType:       UnionMemberAdded,
-				criticalityLevel: Dangerous,
+				criticalityLevel: defaultCriticality(UnionMemberAdded),
 				message:          fmt.Sprintf("Member '%s' was added to Union type '%s'", nt, nDef.Name),
 				path:             oDef.Name,
 				position:         nDef.Position,
+				oldPosition:      oDef.Position,
+				newPosition:      nDef.Position,
 			})
 		}
 	}
@@ -793,10 +1125,11 @@ func changeInFields(oDef *ast.Definition, nDef *ast.Definition) []*Change {
 			}
 			changes = append(changes, &Change{
 				changeType:       FieldRemoved,
-				criticalityLevel: Breaking,
+				criticalityLevel: defaultCriticality(FieldRemoved),
 				message:          msg,
 				path:             fmt.Sprintf("%s.%s", oDef.Name, of.Name),
 				position:         nDef.Position,
+				oldPosition:      of.Position,
 			})
 		} else {
 			//Check field type
@@ -805,10 +1138,12 @@ func changeInFields(oDef *ast.Definition, nDef *ast.Definition) []*Change {
 			if of.Description != nf.Description {
 				changes = append(changes, &Change{
 					changeType:       FieldDescriptionChanged,
-					criticalityLevel: NonBreaking,
+					criticalityLevel: defaultCriticality(FieldDescriptionChanged),
 					message:          fmt.Sprintf("Field '%s.%s' description changed in %s", oDef.Name, of.Name, oDef.Kind),
 					path:             fmt.Sprintf("%s.%s", oDef.Name, of.Name),
 					position:         nf.Position,
+					oldPosition:      of.Position,
+					newPosition:      nf.Position,
 				})
 			}
 			//Check deprecation changes
@@ -825,16 +1160,18 @@ func changeInFields(oDef *ast.Definition, nDef *ast.Definition) []*Change {
 func checkFieldTypeChanged(oDef *ast.Definition, of *ast.FieldDefinition, nf *ast.FieldDefinition) []*Change {
 	var changes []*Change
 	if of.Type.String() != nf.Type.String() {
-		cl := NonBreaking
+		typeContext := compatibleTypeContext
 		if !isSafeChangeForFieldType(of.Type, nf.Type) {
-			cl = Breaking
+			typeContext = incompatibleTypeContext
 		}
 		changes = append(changes, &Change{
 			changeType:       FieldTypeChanged,
-			criticalityLevel: cl,
+			criticalityLevel: defaultCriticalityIn(FieldTypeChanged, typeContext),
 			message:          fmt.Sprintf("Field '%s.%s' type changed from '%s' to '%s' in %s ", oDef.Name, of.Name, of.Type.String(), nf.Type.String(), oDef.Kind),
 			path:             fmt.Sprintf("%s.%s", oDef.Name, of.Name),
 			position:         nf.Position,
+			oldPosition:      of.Position,
+			newPosition:      nf.Position,
 		})
 	}
 	return changes
@@ -851,10 +1188,12 @@ func checkFieldDeprecationChanged(oDef *ast.Definition, nf *ast.FieldDefinition,
 		}
 		changes = append(changes, &Change{
 			changeType:       changeType,
-			criticalityLevel: Dangerous,
+			criticalityLevel: defaultCriticality(changeType),
 			message:          fmt.Sprintf("Field '%s.%s' deprecated in %s ", oDef.Name, of.Name, oDef.Kind),
 			path:             fmt.Sprintf("%s.%s", oDef.Name, of.Name),
 			position:         nf.Position,
+			oldPosition:      of.Position,
+			newPosition:      nf.Position,
 		})
 	}
 	if oDep != nil && nDep == nil {
@@ -864,10 +1203,12 @@ func checkFieldDeprecationChanged(oDef *ast.Definition, nf *ast.FieldDefinition,
 		}
 		changes = append(changes, &Change{
 			changeType:       changeType,
-			criticalityLevel: Dangerous,
+			criticalityLevel: defaultCriticality(changeType),
 			message:          fmt.Sprintf("Field '%s.%s' deprecation removed in %s ", oDef.Name, of.Name, oDef.Kind),
 			path:             fmt.Sprintf("%s.%s", oDef.Name, of.Name),
 			position:         nf.Position,
+			oldPosition:      of.Position,
+			newPosition:      nf.Position,
 		})
 	}
 	if oDep != nil && nDep != nil {
@@ -880,10 +1221,12 @@ func checkFieldDeprecationChanged(oDef *ast.Definition, nf *ast.FieldDefinition,
 			}
 			changes = append(changes, &Change{
 				changeType:       changeType,
-				criticalityLevel: NonBreaking,
+				criticalityLevel: defaultCriticality(changeType),
 				message:          fmt.Sprintf("Field '%s.%s' deprecation reason changed in %s ", oDef.Name, of.Name, oDef.Kind),
 				path:             fmt.Sprintf("%s.%s", oDef.Name, of.Name),
 				position:         nf.Position,
+				oldPosition:      of.Position,
+				newPosition:      nf.Position,
 			})
 		}
 	}
@@ -897,10 +1240,11 @@ func checkFieldsAdded(oDef *ast.Definition, nDef *ast.Definition) []*Change {
 			//Field added to the type
 			changes = append(changes, &Change{
 				changeType:       FieldAdded,
-				criticalityLevel: NonBreaking,
+				criticalityLevel: defaultCriticality(FieldAdded),
 				message:          fmt.Sprintf("Field '%s.%s' was added to %s", nDef.Name, nf.Name, nDef.Kind),
 				path:             fmt.Sprintf("%s.%s", oDef.Name, nf.Name),
 				position:         nf.Position,
+				newPosition:      nf.Position,
 			})
 		}
 	}
@@ -915,22 +1259,26 @@ func changeInArgument(oDef *ast.FieldDefinition, nDef *ast.FieldDefinition, type
 			//Removing a field argument is a breaking change because it will cause existing queries that use this argument to error.
 			changes = append(changes, &Change{
 				changeType:       FieldArgumentRemoved,
-				criticalityLevel: Breaking,
+				criticalityLevel: defaultCriticality(FieldArgumentRemoved),
 				message:          fmt.Sprintf("Argument '%s:%s' was removed from field '%s.%s'", oArg.Name, oArg.Type.String(), typeName, nDef.Name),
 				path:             fmt.Sprintf("%s.%s.%s", typeName, oDef.Name, oArg.Name),
 				position:         nDef.Position,
+				oldPosition:      oArg.Position,
 			})
 		} else {
 			//check argument type change
 			changes = append(changes, checkFieldArgumentTypeChanged(oArg, nArg, typeName, oDef.Name)...)
 			//Changing the default value for an argument may change the runtime behaviour of a field if it was never provided.
-			if oArg.DefaultValue.String() != nArg.DefaultValue.String() {
+			if diff := newValueDiff(oArg.DefaultValue, nArg.DefaultValue); diff != nil {
 				changes = append(changes, &Change{
 					changeType:       FieldArgumentDefaultChanged,
-					criticalityLevel: Dangerous,
+					diff:             diff,
+					criticalityLevel: defaultCriticality(FieldArgumentDefaultChanged),
 					message:          fmt.Sprintf("Argument '%s' default value changed from '%s' to '%s' in '%s.%s' ", oArg.Name, oArg.DefaultValue.String(), nArg.DefaultValue.String(), typeName, oDef.Name),
 					path:             fmt.Sprintf("%s.%s.%s", typeName, oDef.Name, oArg.Name),
 					position:         nArg.Position,
+					oldPosition:      oArg.Position,
+					newPosition:      nArg.Position,
 				})
 			}
 
@@ -938,12 +1286,15 @@ func changeInArgument(oDef *ast.FieldDefinition, nDef *ast.FieldDefinition, type
 			if oArg.Description != nArg.Description {
 				changes = append(changes, &Change{
 					changeType:       FieldArgumentDescriptionChanged,
-					criticalityLevel: NonBreaking,
+					criticalityLevel: defaultCriticality(FieldArgumentDescriptionChanged),
 					message:          fmt.Sprintf("Argument '%s' description changed in '%s.%s' ", oArg.Name, typeName, oDef.Name),
 					path:             fmt.Sprintf("%s.%s.%s", typeName, oDef.Name, oArg.Name),
 					position:         nArg.Position,
+					oldPosition:      oArg.Position,
+					newPosition:      nArg.Position,
 				})
 			}
+			changes = append(changes, changeInTypeFieldDirectives(oArg.Directives, nArg.Directives, fmt.Sprintf("%s.%s.%s", typeName, oDef.Name, oArg.Name), nArg.Position)...)
 		}
 	}
 	changes = append(changes, checkFieldArgumentAdded(oDef, nDef, typeName)...)
@@ -954,17 +1305,19 @@ func checkFieldArgumentTypeChanged(oArg *ast.ArgumentDefinition, nArg *ast.Argum
 	var changes []*Change
 	if oArg.Type.String() != nArg.Type.String() {
 		//Changing an input field from non-null to null is considered non-breaking.
-		cl := NonBreaking
+		typeContext := compatibleTypeContext
 		if !isSafeChangeForInputValue(oArg.Type, nArg.Type) {
 			//Changing the type of a field's argument can cause existing queries that use this argument to error.
-			cl = Breaking
+			typeContext = incompatibleTypeContext
 		}
 		changes = append(changes, &Change{
 			changeType:       FieldArgumentTypeChanged,
-			criticalityLevel: cl,
+			criticalityLevel: defaultCriticalityIn(FieldArgumentTypeChanged, typeContext),
 			message:          fmt.Sprintf("Argument '%s' type changed from '%s' to '%s' in '%s.%s' ", oArg.Name, oArg.Type.String(), nArg.Type.String(), typeName, fieldName),
 			path:             fmt.Sprintf("%s.%s.%s", typeName, fieldName, oArg.Name),
 			position:         nArg.Position,
+			oldPosition:      oArg.Position,
+			newPosition:      nArg.Position,
 		})
 	}
 	return changes
@@ -979,19 +1332,21 @@ func checkFieldArgumentAdded(oDef *ast.FieldDefinition, nDef *ast.FieldDefinitio
 			if nArg.Type.NonNull {
 				changes = append(changes, &Change{
 					changeType:       FieldArgumentAdded,
-					criticalityLevel: Breaking,
+					criticalityLevel: defaultCriticalityIn(FieldArgumentAdded, requiredContext),
 					message:          fmt.Sprintf("Required argument '%s:%s' was added to field '%s.%s'", nArg.Name, nArg.Type.String(), typeName, nDef.Name),
 					path:             fmt.Sprintf("%s.%s.%s", typeName, oDef.Name, nArg.Name),
 					position:         nArg.Position,
+					newPosition:      nArg.Position,
 				})
 			} else {
 				//Adding a new argument to an existing field may involve a change in resolve function logic that potentially may cause some side effects.
 				changes = append(changes, &Change{
 					changeType:       FieldArgumentAdded,
-					criticalityLevel: Dangerous,
+					criticalityLevel: defaultCriticalityIn(FieldArgumentAdded, optionalContext),
 					message:          fmt.Sprintf("Argument '%s:%s' was added to field '%s.%s'", nArg.Name, nArg.Type.String(), typeName, nDef.Name),
 					path:             fmt.Sprintf("%s.%s.%s", typeName, oDef.Name, nArg.Name),
 					position:         nArg.Position,
+					newPosition:      nArg.Position,
 				})
 			}
 		}
@@ -1012,10 +1367,11 @@ func changeInInputFields(oDef *ast.Definition, nDef *ast.Definition) []*Change {
 			}
 			changes = append(changes, &Change{
 				changeType:       InputFieldRemoved,
-				criticalityLevel: Breaking,
+				criticalityLevel: defaultCriticality(InputFieldRemoved),
 				message:          msg,
 				path:             fmt.Sprintf("%s.%s", oDef.Name, of.Name),
 				position:         nDef.Position,
+				oldPosition:      of.Position,
 			})
 		} else {
 			//Check input field type
@@ -1024,10 +1380,12 @@ func changeInInputFields(oDef *ast.Definition, nDef *ast.Definition) []*Change {
 			if of.Description != nf.Description {
 				changes = append(changes, &Change{
 					changeType:       InputFieldDescriptionChanged,
-					criticalityLevel: NonBreaking,
+					criticalityLevel: defaultCriticality(InputFieldDescriptionChanged),
 					message:          fmt.Sprintf("Input field '%s.%s' description changed in input object type", oDef.Name, of.Name),
 					path:             fmt.Sprintf("%s.%s", oDef.Name, of.Name),
 					position:         nf.Position,
+					oldPosition:      of.Position,
+					newPosition:      nf.Position,
 				})
 			}
 			//Check deprecation changes
@@ -1044,27 +1402,32 @@ func checkInputFieldTypeValueChanged(oDef *ast.Definition, of *ast.FieldDefiniti
 	var changes []*Change
 	if of.Type.String() != nf.Type.String() {
 		//Changing an input field from non-null to null is considered non-breaking.
-		cl := NonBreaking
+		typeContext := compatibleTypeContext
 		if !isSafeChangeForInputValue(of.Type, nf.Type) {
 			//Changing the type of an input field can cause existing queries that use this field to error.
-			cl = Breaking
+			typeContext = incompatibleTypeContext
 		}
 		changes = append(changes, &Change{
 			changeType:       InputFieldTypeChanged,
-			criticalityLevel: cl,
+			criticalityLevel: defaultCriticalityIn(InputFieldTypeChanged, typeContext),
 			message:          fmt.Sprintf("Input field '%s.%s' type changed from '%s' to '%s' in input object type", oDef.Name, of.Name, of.Type.String(), nf.Type.String()),
 			path:             fmt.Sprintf("%s.%s", oDef.Name, of.Name),
 			position:         nf.Position,
+			oldPosition:      of.Position,
+			newPosition:      nf.Position,
 		})
 	}
 	//Changing the default value for an argument may change the runtime behaviour of a field if it was never provided.
-	if of.DefaultValue.String() != nf.DefaultValue.String() {
+	if diff := newValueDiff(of.DefaultValue, nf.DefaultValue); diff != nil {
 		changes = append(changes, &Change{
 			changeType:       InputFieldDefaultValueChanged,
-			criticalityLevel: Dangerous,
+			diff:             diff,
+			criticalityLevel: defaultCriticality(InputFieldDefaultValueChanged),
 			message:          fmt.Sprintf("Input field '%s.%s' default value changed from '%s' to '%s' in input object type", oDef.Name, of.Name, of.DefaultValue.String(), nf.DefaultValue.String()),
 			path:             fmt.Sprintf("%s.%s", oDef.Name, of.Name),
 			position:         nf.Position,
+			oldPosition:      of.Position,
+			newPosition:      nf.Position,
 		})
 	}
 	return changes
@@ -1079,18 +1442,20 @@ func checkInputFieldsAdded(oDef *ast.Definition, nDef *ast.Definition) []*Change
 				//Adding a required input field to an existing input object type is a breaking change because it will cause existing uses of this input object type to error.
 				changes = append(changes, &Change{
 					changeType:       InputFieldAdded,
-					criticalityLevel: Breaking,
+					criticalityLevel: defaultCriticalityIn(InputFieldAdded, requiredContext),
 					message:          fmt.Sprintf("Required field '%s' was added to input object type '%s'", nf.Name, nDef.Name),
 					path:             fmt.Sprintf("%s.%s", oDef.Name, nf.Name),
 					position:         nf.Position,
+					newPosition:      nf.Position,
 				})
 			} else {
 				changes = append(changes, &Change{
 					changeType:       InputFieldAdded,
-					criticalityLevel: Dangerous,
+					criticalityLevel: defaultCriticalityIn(InputFieldAdded, optionalContext),
 					message:          fmt.Sprintf("Field '%s' was added to input object type '%s'", nf.Name, nDef.Name),
 					path:             fmt.Sprintf("%s.%s", oDef.Name, nf.Name),
 					position:         nf.Position,
+					newPosition:      nf.Position,
 				})
 			}
 		}
@@ -1100,55 +1465,34 @@ func checkInputFieldsAdded(oDef *ast.Definition, nDef *ast.Definition) []*Change
 
 func changeInTypeFieldDirectives(oDirs ast.DirectiveList, nDirs ast.DirectiveList, typeName string, pos *ast.Position) []*Change {
 	var changes []*Change
+	compared := map[string]bool{}
 	for _, od := range oDirs {
+		if od.Name == deprecatedDirective || compared[od.Name] {
+			continue
+		}
+		compared[od.Name] = true
 		oDirList := oDirs.ForNames(od.Name) //if the directive is repetitive
-		if od.Name != deprecatedDirective {
-			nDirList := nDirs.ForNames(od.Name)
-			switch {
-			case len(nDirList) == 0:
+		nDirList := nDirs.ForNames(od.Name)
+		switch {
+		case len(nDirList) == 0:
+			for _, removed := range oDirList {
 				changes = append(changes, &Change{
 					changeType:       DirectiveRemoved,
-					criticalityLevel: Dangerous,
-					message:          fmt.Sprintf("Directive '@%s' was removed from '%s'", od.Name, typeName),
+					directive:        od.Name,
+					criticalityLevel: defaultCriticalityIn(DirectiveRemoved, directiveUsageContext),
+					message:          fmt.Sprintf("Directive '%s' was removed from '%s'", directiveUsage(removed, len(oDirList) > 1), typeName),
 					path:             typeName,
 					position:         pos,
+					oldPosition:      removed.Position,
 				})
-			case len(nDirList) == 1 && len(oDirList) == 1:
-				//means there is only one directive, check for the argument changes
-				nd := nDirList[0]
-				changes = append(changes, checkFieldDirectiveArgumentChanged(od, nd, typeName, pos)...)
-				changes = append(changes, checkFieldDirectiveArgumentAdded(od, nd, typeName)...)
-			default:
-				//check if at least one directive from the list matches arguments
-				haveSameArgVals := false
-				for _, nd := range nDirList {
-					if len(od.Arguments) == len(nd.Arguments) {
-						for _, oArg := range od.Arguments {
-							nArg := nd.Arguments.ForName(oArg.Name)
-							if nArg != nil && oArg.Value.String() == nArg.Value.String() {
-								haveSameArgVals = true // args checked till now are same
-								continue
-							}
-							// We found first arg not matching so not need to check more args
-							haveSameArgVals = false
-							break
-						}
-						if haveSameArgVals {
-							// means the directive have all the arguments matching so no need to check other directives in the list
-							break
-						}
-					}
-				}
-				if !haveSameArgVals {
-					changes = append(changes, &Change{
-						changeType:       DirectiveChanged,
-						criticalityLevel: Dangerous,
-						message:          fmt.Sprintf("Directive '@%s' was changed on '%s'", od.Name, typeName),
-						path:             typeName,
-						position:         pos,
-					})
-				}
 			}
+		case len(nDirList) == 1 && len(oDirList) == 1:
+			//means there is only one directive, check for the argument changes
+			nd := nDirList[0]
+			changes = append(changes, checkFieldDirectiveArgumentChanged(od, nd, typeName, pos)...)
+			changes = append(changes, checkFieldDirectiveArgumentAdded(od, nd, typeName)...)
+		default:
+			changes = append(changes, changeInRepeatedDirectives(oDirList, nDirList, typeName, pos)...)
 		}
 	}
 	changes = append(changes, checkFieldDirectiveAdded(oDirs, nDirs, typeName)...)
@@ -1163,10 +1507,12 @@ func checkFieldDirectiveArgumentAdded(od *ast.Directive, nd *ast.Directive, type
 			//argument added to the field
 			changes = append(changes, &Change{
 				changeType:       DirectiveArgumentAdded,
-				criticalityLevel: NonBreaking,
+				directive:        nd.Name,
+				criticalityLevel: defaultCriticalityIn(DirectiveArgumentAdded, directiveUsageContext),
 				message:          fmt.Sprintf("Directive '@%s' argument '%s' was added to in '%s'", nd.Name, nArg.Name, typeName),
 				path:             fmt.Sprintf("%s.@%s", typeName, od.Name),
 				position:         nArg.Position,
+				newPosition:      nArg.Position,
 			})
 		}
 	}
@@ -1181,24 +1527,115 @@ func checkFieldDirectiveArgumentChanged(od *ast.Directive, nd *ast.Directive, ty
 			//argument is removed
 			changes = append(changes, &Change{
 				changeType:       DirectiveArgumentRemoved,
-				criticalityLevel: Dangerous,
+				directive:        od.Name,
+				criticalityLevel: defaultCriticalityIn(DirectiveArgumentRemoved, directiveUsageContext),
 				message:          fmt.Sprintf("Directive '@%s' argument '%s' was removed in '%s'", od.Name, oArg.Name, typeName),
 				path:             fmt.Sprintf("@%s.%s", od.Name, oArg.Name),
 				position:         pos,
+				oldPosition:      oArg.Position,
 			})
-		} else if oArg.Value.String() != nArg.Value.String() {
+		} else if normalizeArgumentValue(od.Name, oArg.Name, oArg.Value) != normalizeArgumentValue(nd.Name, nArg.Name, nArg.Value) {
 			changes = append(changes, &Change{
 				changeType:       DirectiveArgumentValueChanged,
-				criticalityLevel: Dangerous,
+				directive:        od.Name,
+				diff:             directiveArgumentDiff(od.Name, oArg, nArg),
+				criticalityLevel: defaultCriticality(DirectiveArgumentValueChanged),
 				message:          fmt.Sprintf("Directive '@%s' argument '%s' value changed from '%s' to '%s' in '%s' ", od.Name, oArg.Name, oArg.Value.String(), nArg.Value.String(), typeName),
 				path:             fmt.Sprintf("@%s.%s", od.Name, oArg.Name),
 				position:         nArg.Position,
+				oldPosition:      oArg.Position,
+				newPosition:      nArg.Position,
 			})
 		}
 	}
 	return changes
 }
 
+// changeInRepeatedDirectives diffs the usages of a repeatable directive as multisets keyed by their argument values,
+// usages left after matching the equal ones are reported as changed in pairs, and the rest as removed or added
+func changeInRepeatedDirectives(oDirList ast.DirectiveList, nDirList ast.DirectiveList, typeName string, pos *ast.Position) []*Change {
+	var changes []*Change
+	unmatched := map[string]ast.DirectiveList{}
+	for _, nd := range nDirList {
+		key := directiveArgumentsKey(nd)
+		unmatched[key] = append(unmatched[key], nd)
+	}
+	var removed ast.DirectiveList
+	for _, od := range oDirList {
+		key := directiveArgumentsKey(od)
+		if len(unmatched[key]) != 0 {
+			unmatched[key] = unmatched[key][1:]
+			continue
+		}
+		removed = append(removed, od)
+	}
+	var added ast.DirectiveList
+	for _, nd := range nDirList {
+		key := directiveArgumentsKey(nd)
+		if len(unmatched[key]) != 0 && unmatched[key][0] == nd {
+			unmatched[key] = unmatched[key][1:]
+			added = append(added, nd)
+		}
+	}
+	for i := 0; i < len(removed) && i < len(added); i++ {
+		changes = append(changes, &Change{
+			changeType:       DirectiveChanged,
+			directive:        added[i].Name,
+			criticalityLevel: defaultCriticality(DirectiveChanged),
+			message:          fmt.Sprintf("Directive '%s' was changed to '%s' on '%s'", directiveUsage(removed[i], true), directiveUsage(added[i], true), typeName),
+			path:             typeName,
+			position:         added[i].Position,
+			oldPosition:      removed[i].Position,
+			newPosition:      added[i].Position,
+		})
+	}
+	for i := len(added); i < len(removed); i++ {
+		changes = append(changes, &Change{
+			changeType:       DirectiveRemoved,
+			directive:        removed[i].Name,
+			criticalityLevel: defaultCriticalityIn(DirectiveRemoved, directiveUsageContext),
+			message:          fmt.Sprintf("Directive '%s' was removed from '%s'", directiveUsage(removed[i], true), typeName),
+			path:             typeName,
+			position:         pos,
+			oldPosition:      removed[i].Position,
+		})
+	}
+	for i := len(removed); i < len(added); i++ {
+		changes = append(changes, &Change{
+			changeType:       DirectiveAdded,
+			directive:        added[i].Name,
+			criticalityLevel: defaultCriticality(DirectiveAdded),
+			message:          fmt.Sprintf("Directive '%s' was added in '%s'", directiveUsage(added[i], true), typeName),
+			path:             typeName,
+			position:         added[i].Position,
+			newPosition:      added[i].Position,
+		})
+	}
+	return changes
+}
+
+// directiveArgumentsKey identifies a directive usage by its arguments sorted by name
+func directiveArgumentsKey(d *ast.Directive) string {
+	arguments := make([]string, 0, len(d.Arguments))
+	for _, arg := range d.Arguments {
+		arguments = append(arguments, fmt.Sprintf("%s: %s", arg.Name, normalizeArgumentValue(d.Name, arg.Name, arg.Value)))
+	}
+	sort.Strings(arguments)
+	return strings.Join(arguments, ", ")
+}
+
+// directiveUsage prints the directive name, with its arguments when the usages of a repeated directive have to be told apart
+func directiveUsage(d *ast.Directive, withArguments bool) string {
+	if !withArguments || len(d.Arguments) == 0 {
+		return fmt.Sprintf("@%s", d.Name)
+	}
+	arguments := make([]string, 0, len(d.Arguments))
+	for _, arg := range d.Arguments {
+		arguments = append(arguments, fmt.Sprintf("%s: %s", arg.Name, arg.Value.String()))
+	}
+	return fmt.Sprintf("@%s(%s)", d.Name, strings.Join(arguments, ", "))
+}
+
 func checkFieldDirectiveAdded(oDirs ast.DirectiveList, nDirs ast.DirectiveList, typeName string) []*Change {
 	var changes []*Change
 	for _, nd := range nDirs {
@@ -1207,10 +1644,12 @@ func checkFieldDirectiveAdded(oDirs ast.DirectiveList, nDirs ast.DirectiveList,
 			if od == nil {
 				changes = append(changes, &Change{
 					changeType:       DirectiveAdded,
-					criticalityLevel: NonBreaking,
-					message:          fmt.Sprintf("Directive '@%s' was added in '%s'", nd.Name, typeName),
+					directive:        nd.Name,
+					criticalityLevel: defaultCriticality(DirectiveAdded),
+					message:          fmt.Sprintf("Directive '%s' was added in '%s'", directiveUsage(nd, len(nDirs.ForNames(nd.Name)) > 1), typeName),
 					path:             typeName,
 					position:         nd.Position,
+					newPosition:      nd.Position,
 				})
 			}
 		}
@@ -1300,59 +1739,45 @@ func GroupChanges(changes []*Change) map[Criticality][]*Change {
 }
 
 // ReportBreakingChanges print only breaking changes in output
+//
+// Deprecated: use WriteReport with a TextReporter
 func ReportBreakingChanges(changes []*Change, withFilepath bool) int {
-	if len(changes) == 0 {
-		return 0
-	}
-	sort.Slice(changes, less(changes))
-	for _, c := range changes {
-		if pos := getPosition(c); withFilepath && len(pos) > 0 {
-			fmt.Printf("%s  %s %s\n", "❌", pos, c.message)
-			continue
-		}
-		fmt.Printf("%s  %s\n", "❌", c.message)
-	}
-	return len(changes)
+	return stdoutTextReporter(withFilepath).printChanges("❌", changes)
 }
 
 // ReportDangerousChanges print only breaking changes in output
+//
+// Deprecated: use WriteReport with a TextReporter
 func ReportDangerousChanges(changes []*Change, withFilepath bool) int {
-	if len(changes) == 0 {
-		return 0
-	}
-	sort.Slice(changes, less(changes))
-	for _, c := range changes {
-		if pos := getPosition(c); withFilepath && len(pos) > 0 {
-			fmt.Printf("%s  %s %s\n", "✋️", pos, c.message)
-			continue
-		}
-		fmt.Printf("%s  %s\n", "✋️", c.message)
-	}
-	return len(changes)
+	return stdoutTextReporter(withFilepath).printChanges("✋️", changes)
 }
 
 // ReportNonBreakingChanges print only breaking changes in output
+//
+// Deprecated: use WriteReport with a TextReporter
 func ReportNonBreakingChanges(changes []*Change, withFilepath bool) int {
-	if len(changes) == 0 {
-		return 0
-	}
-	sort.Slice(changes, less(changes))
-	for _, c := range changes {
-		if pos := getPosition(c); withFilepath && len(pos) > 0 {
-			fmt.Printf("%s  %s %s\n", "✅", pos, c.message)
-			continue
-		}
-		fmt.Printf("%s  %s\n", "✅", c.message)
-	}
-	return len(changes)
+	return stdoutTextReporter(withFilepath).printChanges("✅", changes)
 }
 
+// less orders the changes by position, changes at the same position are ordered by path, type and message so the order
+// doesn't depend on the order the changes were found in
 func less(changes []*Change) func(i int, j int) bool {
 	return func(i, j int) bool {
-		if changes[i].position.Src.Name != changes[j].position.Src.Name {
-			return changes[i].position.Src.Name < changes[j].position.Src.Name
+		ci, cj := changes[i], changes[j]
+		switch {
+		case ci.position.Src.Name != cj.position.Src.Name:
+			return ci.position.Src.Name < cj.position.Src.Name
+		case ci.position.Line != cj.position.Line:
+			return ci.position.Line < cj.position.Line
+		case ci.position.Column != cj.position.Column:
+			return ci.position.Column < cj.position.Column
+		case ci.path != cj.path:
+			return ci.path < cj.path
+		case ci.changeType != cj.changeType:
+			return ci.changeType < cj.changeType
+		default:
+			return ci.message < cj.message
 		}
-		return changes[i].position.Line < changes[j].position.Line
 	}
 }
 
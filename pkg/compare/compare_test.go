@@ -1389,7 +1389,7 @@ func TestCompareTypeDirectives(t *testing.T) {
 			}
 			`,
 			criticality: Dangerous,
-			ChangeType:  DirectiveChanged,
+			ChangeType:  DirectiveRemoved,
 		},
 		{
 			name: "repetitive directive is changed ",
@@ -1561,6 +1561,283 @@ func TestCompareFieldDirectives(t *testing.T) {
 	}
 }
 
+func TestCompareRepeatedDirectives(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldSchema   string
+		newSchema   string
+		changeTypes []ChangeType
+		messages    []string
+		lines       []int
+	}{
+		{
+			name:      "reordered repeated directives",
+			oldSchema: `type Book @tag(name: "public") @tag(name: "books") { isbn: String! }`,
+			newSchema: `type Book @tag(name: "books") @tag(name: "public") { isbn: String! }`,
+		},
+		{
+			name:      "reordered arguments of repeated directives",
+			oldSchema: `type Book @graph(type: "book", key: "isbn") @graph(type: "library", key: "id") { isbn: String! }`,
+			newSchema: `type Book @graph(key: "id", type: "library") @graph(key: "isbn", type: "book") { isbn: String! }`,
+		},
+		{
+			name:      "another copy added",
+			oldSchema: `type Book @tag(name: "public") @tag(name: "books") { isbn: String! }`,
+			newSchema: `type Book
+				@tag(name: "public")
+				@tag(name: "books")
+				@tag(name: "internal") { isbn: String! }`,
+			changeTypes: []ChangeType{DirectiveAdded},
+			messages:    []string{`Directive '@tag(name: "internal")' was added in 'Book'`},
+			lines:       []int{4},
+		},
+		{
+			name:        "second copy added to a single directive",
+			oldSchema:   `type Book { isbn: String! @tag(name: "public") }`,
+			newSchema:   `type Book { isbn: String! @tag(name: "public") @tag(name: "books") }`,
+			changeTypes: []ChangeType{DirectiveAdded},
+			messages:    []string{`Directive '@tag(name: "books")' was added in 'Book.isbn'`},
+			lines:       []int{1},
+		},
+		{
+			name:      "copies changed and removed",
+			oldSchema: `enum Status { AVAILABLE @tag(name: "a") @tag(name: "b") @tag(name: "c") }`,
+			newSchema: `enum Status {
+				AVAILABLE
+					@tag(name: "a")
+					@tag(name: "d")
+			}`,
+			changeTypes: []ChangeType{DirectiveChanged, DirectiveRemoved},
+			messages: []string{
+				`Directive '@tag(name: "b")' was changed to '@tag(name: "d")' on 'Status.AVAILABLE'`,
+				`Directive '@tag(name: "c")' was removed from 'Status.AVAILABLE'`,
+			},
+			lines: []int{4, 2},
+		},
+		{
+			name:        "copies added to a type without the directive",
+			oldSchema:   `type Book { isbn: String! }`,
+			newSchema:   `type Book @tag(name: "public") @tag(name: "books") { isbn: String! }`,
+			changeTypes: []ChangeType{DirectiveAdded, DirectiveAdded},
+			messages: []string{
+				`Directive '@tag(name: "public")' was added in 'Book'`,
+				`Directive '@tag(name: "books")' was added in 'Book'`,
+			},
+			lines: []int{1, 1},
+		},
+		{
+			name:        "all copies removed",
+			oldSchema:   `type Book @tag(name: "public") @tag(name: "books") { isbn: String! }`,
+			newSchema:   `type Book { isbn: String! }`,
+			changeTypes: []ChangeType{DirectiveRemoved, DirectiveRemoved},
+			messages: []string{
+				`Directive '@tag(name: "public")' was removed from 'Book'`,
+				`Directive '@tag(name: "books")' was removed from 'Book'`,
+			},
+			lines: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema)
+			if len(changes) != len(tt.changeTypes) {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			for i, changeType := range tt.changeTypes {
+				if changes[i].changeType != changeType || changes[i].message != tt.messages[i] || changes[i].position.Line != tt.lines[i] {
+					t.Errorf("change = %v, want %v %q at line %d", changes[i], changeType, tt.messages[i], tt.lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCompareScalarTypes(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldSchema   string
+		newSchema   string
+		criticality Criticality
+		ChangeType  ChangeType
+	}{
+		{
+			name: "Scalar specifiedBy added",
+			oldSchema: `
+			scalar UUID
+			`,
+			newSchema: `
+			scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")
+			`,
+			criticality: NonBreaking,
+			ChangeType:  ScalarSpecifiedByAdded,
+		},
+		{
+			name: "Scalar specifiedBy removed",
+			oldSchema: `
+			scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")
+			`,
+			newSchema: `
+			scalar UUID
+			`,
+			criticality: Dangerous,
+			ChangeType:  ScalarSpecifiedByRemoved,
+		},
+		{
+			name: "Scalar specifiedBy url changed",
+			oldSchema: `
+			scalar DateTime @specifiedBy(url: "https://tools.ietf.org/html/rfc3339")
+			`,
+			newSchema: `
+			scalar DateTime @specifiedBy(url: "https://www.iso.org/iso-8601-date-and-time-format.html")
+			`,
+			criticality: Dangerous,
+			ChangeType:  ScalarSpecifiedByChanged,
+		},
+		{
+			name: "Scalar directive removed",
+			oldSchema: `
+			scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122") @exposure(scope: [PUBLIC])
+			`,
+			newSchema: `
+			scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")
+			`,
+			criticality: Dangerous,
+			ChangeType:  DirectiveRemoved,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema)
+			if len(changes) != 1 {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			if changes[0].criticalityLevel != tt.criticality || changes[0].changeType != tt.ChangeType {
+				t.Errorf("Scalar type changes = %v", changes[0])
+			}
+		})
+	}
+}
+
+func TestCompareDirectivesAtEveryLocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldSchema  string
+		newSchema  string
+		ChangeType ChangeType
+		path       string
+	}{
+		{
+			name:       "Schema directive removed",
+			oldSchema:  `schema @link(url: "https://specs.apollo.dev/federation/v2.0") { query: Query } type Query { a: Int }`,
+			newSchema:  `schema { query: Query } type Query { a: Int }`,
+			ChangeType: DirectiveRemoved,
+			path:       "schema",
+		},
+		{
+			name:       "Schema directive argument value changed",
+			oldSchema:  `schema @link(url: "https://specs.apollo.dev/federation/v2.0") { query: Query } type Query { a: Int }`,
+			newSchema:  `schema @link(url: "https://specs.apollo.dev/federation/v2.3") { query: Query } type Query { a: Int }`,
+			ChangeType: DirectiveArgumentValueChanged,
+			path:       "@link.url",
+		},
+		{
+			name:       "Enum directive added",
+			oldSchema:  `enum Status { AVAILABLE }`,
+			newSchema:  `enum Status @exposure(scope: [PUBLIC]) { AVAILABLE }`,
+			ChangeType: DirectiveAdded,
+			path:       "Status",
+		},
+		{
+			name:       "Enum value directive removed",
+			oldSchema:  `enum Status { AVAILABLE @exposure(scope: [PUBLIC]) }`,
+			newSchema:  `enum Status { AVAILABLE }`,
+			ChangeType: DirectiveRemoved,
+			path:       "Status.AVAILABLE",
+		},
+		{
+			name:       "Input object directive removed",
+			oldSchema:  `input BookInput @oneOf { isbn: String title: String }`,
+			newSchema:  `input BookInput { isbn: String title: String }`,
+			ChangeType: DirectiveRemoved,
+			path:       "BookInput",
+		},
+		{
+			name:       "Union directive added",
+			oldSchema:  `union Body = Image | Text`,
+			newSchema:  `union Body @exposure(scope: [PUBLIC]) = Image | Text`,
+			ChangeType: DirectiveAdded,
+			path:       "Body",
+		},
+		{
+			name:       "Field argument directive removed",
+			oldSchema:  `type Query { books(first: Int @constraint(max: 100)): [String] }`,
+			newSchema:  `type Query { books(first: Int): [String] }`,
+			ChangeType: DirectiveRemoved,
+			path:       "Query.books.first",
+		},
+		{
+			name:       "Directive argument directive added",
+			oldSchema:  `directive @cached(ttl: Int) on FIELD`,
+			newSchema:  `directive @cached(ttl: Int @constraint(min: 0)) on FIELD`,
+			ChangeType: DirectiveAdded,
+			path:       "@cached.ttl",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema)
+			if len(changes) != 1 {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			if changes[0].changeType != tt.ChangeType || changes[0].path != tt.path || changes[0].position == nil {
+				t.Errorf("Directive changes = %v", changes[0])
+			}
+		})
+	}
+}
+
 func TestCompareSchemaFiles(t *testing.T) {
 	t.Run("Compare schema files", func(t *testing.T) {
 		sourceDir := "./test_schema"
@@ -0,0 +1,229 @@
+package compare
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// changeContext narrows a change type to the context its default criticality depends on
+type changeContext string
+
+const (
+	// anyContext is the context of the change types whose criticality doesn't depend on the context
+	anyContext changeContext = ""
+	// requiredContext is an added argument or input field which is non-null, so clients have to pass it
+	requiredContext changeContext = "required"
+	// optionalContext is an added argument or input field which is nullable, so clients don't have to pass it
+	optionalContext changeContext = "optional"
+	// compatibleTypeContext is a type changed to one the existing clients are compatible with e.g. String! to String of an argument
+	compatibleTypeContext changeContext = "compatible type"
+	// incompatibleTypeContext is a type changed to one the existing clients aren't compatible with
+	incompatibleTypeContext changeContext = "incompatible type"
+	// directiveDefinitionContext is a change of a directive definition, which fails the documents using the directive
+	directiveDefinitionContext changeContext = "directive definition"
+	// directiveUsageContext is a change of a directive applied to the schema, a type, a field, an argument or an enum value
+	directiveUsageContext changeContext = "directive usage"
+	// rootAddedContext, rootRemovedContext and rootChangedContext are the changes of a root operation type of the schema
+	rootAddedContext   changeContext = "root added"
+	rootRemovedContext changeContext = "root removed"
+	rootChangedContext changeContext = "root changed"
+	// federationV1Context and federationV2Context are the changes compared with the rules of the Apollo Federation version
+	federationV1Context changeContext = "federation v1"
+	federationV2Context changeContext = "federation v2"
+	// inputEnumContext is a change of an enum only used as input, its values are never returned to clients
+	inputEnumContext changeContext = "input enum"
+	// outputEnumContext is a change of an enum only used as output, clients never send its values
+	outputEnumContext changeContext = "output enum"
+)
+
+// defaultCriticalities are the criticalities of the change types, in every context the criticality of a change type depends on.
+// Every change is classified with this table, the criticality overrides are applied on top of it.
+var defaultCriticalities = map[ChangeType]map[changeContext]Criticality{
+	FieldArgumentDescriptionChanged:      {anyContext: NonBreaking},
+	FieldArgumentDefaultChanged:          {anyContext: Dangerous},
+	FieldArgumentTypeChanged:             {compatibleTypeContext: NonBreaking, incompatibleTypeContext: Breaking},
+	DirectiveRemoved:                     {directiveDefinitionContext: Breaking, directiveUsageContext: Dangerous},
+	DirectiveChanged:                     {anyContext: Dangerous},
+	DirectiveAdded:                       {anyContext: NonBreaking},
+	DirectiveDescriptionChanged:          {anyContext: NonBreaking},
+	DirectiveLocationAdded:               {anyContext: NonBreaking},
+	DirectiveLocationRemoved:             {anyContext: Breaking},
+	DirectiveArgumentAdded:               {requiredContext: Breaking, optionalContext: NonBreaking, directiveUsageContext: NonBreaking},
+	DirectiveArgumentRemoved:             {directiveDefinitionContext: Breaking, directiveUsageContext: Dangerous},
+	DirectiveArgumentDescriptionChanged:  {anyContext: NonBreaking},
+	DirectiveArgumentDefaultValueChanged: {anyContext: Dangerous},
+	DirectiveArgumentTypeChanged:         {compatibleTypeContext: NonBreaking, incompatibleTypeContext: Breaking},
+	DirectiveRepeatableRemoved:           {anyContext: Breaking},
+	DirectiveRepeatableAdded:             {anyContext: NonBreaking},
+	DirectiveArgumentValueChanged:        {anyContext: Dangerous},
+	EnumValueRemoved:                     {anyContext: Breaking, outputEnumContext: Dangerous},
+	EnumValueAdded:                       {anyContext: Dangerous, inputEnumContext: NonBreaking},
+	EnumValueDescriptionChanged:          {anyContext: NonBreaking},
+	EnumValueDeprecationReasonChanged:    {anyContext: NonBreaking},
+	EnumValueDeprecationAdded:            {anyContext: Dangerous},
+	FieldRemoved:                         {anyContext: Breaking},
+	FieldAdded:                           {anyContext: NonBreaking},
+	FieldDescriptionChanged:              {anyContext: NonBreaking},
+	FieldDeprecationAdded:                {anyContext: Dangerous},
+	FieldDeprecationRemoved:              {anyContext: Dangerous},
+	FieldDeprecationReasonChanged:        {anyContext: NonBreaking},
+	FieldTypeChanged:                     {compatibleTypeContext: NonBreaking, incompatibleTypeContext: Breaking},
+	FieldArgumentAdded:                   {requiredContext: Breaking, optionalContext: Dangerous},
+	FieldArgumentRemoved:                 {anyContext: Breaking},
+	InputFieldRemoved:                    {anyContext: Breaking},
+	InputFieldAdded:                      {requiredContext: Breaking, optionalContext: Dangerous},
+	InputFieldDescriptionChanged:         {anyContext: NonBreaking},
+	InputFieldDefaultValueChanged:        {anyContext: Dangerous},
+	InputFieldTypeChanged:                {compatibleTypeContext: NonBreaking, incompatibleTypeContext: Breaking},
+	ObjectTypeInterfaceAdded:             {anyContext: Dangerous},
+	InputFieldDeprecationAdded:           {anyContext: Dangerous},
+	InputFieldDeprecationRemoved:         {anyContext: Dangerous},
+	InputFieldDeprecationReasonChanged:   {anyContext: NonBreaking},
+	ObjectTypeInterfaceRemoved:           {anyContext: Breaking},
+	SchemaQueryTypeChanged:               {rootAddedContext: NonBreaking, rootRemovedContext: Breaking, rootChangedContext: Breaking},
+	SchemaMutationTypeChanged:            {rootAddedContext: NonBreaking, rootRemovedContext: Breaking, rootChangedContext: Breaking},
+	SchemaSubscriptionTypeChanged:        {rootAddedContext: NonBreaking, rootRemovedContext: Breaking, rootChangedContext: Breaking},
+	TypeRemoved:                          {anyContext: Breaking},
+	TypeAdded:                            {anyContext: NonBreaking},
+	TypeKindChanged:                      {anyContext: Breaking},
+	TypeDescriptionChanged:               {anyContext: NonBreaking},
+	UnionMemberRemoved:                   {anyContext: Breaking},
+	UnionMemberAdded:                     {anyContext: Dangerous},
+	FieldRenamed:                         {anyContext: Breaking},
+	TypeRenamed:                          {anyContext: Breaking},
+	EnumValueRenamed:                     {anyContext: Breaking},
+	ArgumentRenamed:                      {anyContext: Breaking},
+	EntityRemoved:                        {anyContext: Breaking},
+	EntityKeyAdded:                       {anyContext: NonBreaking},
+	EntityKeyRemoved:                     {anyContext: Breaking},
+	EntityKeyChanged:                     {anyContext: Breaking},
+	FieldExternalAdded:                   {anyContext: Breaking},
+	FieldExternalRemoved:                 {federationV1Context: Breaking, federationV2Context: Dangerous},
+	FieldRequiresAdded:                   {anyContext: Dangerous},
+	FieldRequiresRemoved:                 {anyContext: NonBreaking},
+	FieldRequiresChanged:                 {anyContext: Dangerous},
+	FieldProvidesAdded:                   {anyContext: NonBreaking},
+	FieldProvidesRemoved:                 {anyContext: Dangerous},
+	FieldProvidesChanged:                 {anyContext: Dangerous},
+	ShareableAdded:                       {anyContext: NonBreaking},
+	ShareableRemoved:                     {anyContext: Breaking},
+	FieldOverrideAdded:                   {anyContext: Dangerous},
+	FieldOverrideRemoved:                 {anyContext: Dangerous},
+	FieldOverrideChanged:                 {anyContext: Dangerous},
+	ScalarSpecifiedByAdded:               {anyContext: NonBreaking},
+	ScalarSpecifiedByRemoved:             {anyContext: Dangerous},
+	ScalarSpecifiedByChanged:             {anyContext: Dangerous},
+	InterfaceTypeInterfaceAdded:          {anyContext: Dangerous},
+	InterfaceTypeInterfaceRemoved:        {anyContext: Breaking},
+	PossibleTypeAdded:                    {anyContext: Dangerous},
+	PossibleTypeRemoved:                  {anyContext: Breaking},
+}
+
+// defaultCriticality is the criticality of the change type whose criticality doesn't depend on the context.
+// A change type missing from the table is assumed to be Breaking, so an unclassified change doesn't go unnoticed.
+func defaultCriticality(changeType ChangeType) Criticality {
+	criticalities, ok := defaultCriticalities[changeType]
+	if !ok {
+		return Breaking
+	}
+	if criticality, ok := criticalities[anyContext]; ok {
+		return criticality
+	}
+	// the criticality depends on a context the change wasn't classified in, the most severe one is kept
+	criticality := NonBreaking
+	for _, c := range criticalities {
+		if c.severity() > criticality.severity() {
+			criticality = c
+		}
+	}
+	return criticality
+}
+
+// defaultCriticalityIn is the criticality of the change type in the context, the criticality of the change type
+// is used when the table has no entry for the context
+func defaultCriticalityIn(changeType ChangeType, context changeContext) Criticality {
+	if criticality, ok := defaultCriticalities[changeType][context]; ok {
+		return criticality
+	}
+	return defaultCriticality(changeType)
+}
+
+// IsChangeType checks whether the change type is one of the change types the compare reports
+func IsChangeType(changeType ChangeType) bool {
+	_, ok := defaultCriticalities[changeType]
+	return ok
+}
+
+// CriticalityOverride changes the criticality of the changes of a type, optionally narrowed to the changes whose path
+// matches a glob or which are about a directive
+type CriticalityOverride struct {
+	// ChangeType is the type of the changes the override applies to e.g. ENUM_VALUE_ADDED
+	ChangeType ChangeType
+	// Path is the glob the change path has to match e.g. Status.* or Query.*, the override applies to any path when it is empty
+	Path string
+	// Directive is the name of the directive the change has to be about e.g. auth, the override applies to any change when it is empty
+	Directive string
+	// Criticality is the criticality of the matching changes
+	Criticality Criticality
+}
+
+// WithCriticalityOverrides overrides the criticality of the changes matching the overrides, the last matching override wins.
+// The overrides are applied after the client operations and the deprecation policy, so the criticality they set is final.
+func WithCriticalityOverrides(overrides []CriticalityOverride) Option {
+	return func(o *options) {
+		o.overrides = append(o.overrides, overrides...)
+	}
+}
+
+// ValidateCriticalityOverrides checks the overrides have a known change type, valid path globs and set the criticality
+// to Breaking, Dangerous or NonBreaking. SafeUnused and Accepted are left to the client operations and the accepted changes file.
+func ValidateCriticalityOverrides(overrides []CriticalityOverride) error {
+	for i, override := range overrides {
+		if len(override.ChangeType) == 0 {
+			return fmt.Errorf("criticality override[%d] expects a changeType", i)
+		}
+		if !IsChangeType(override.ChangeType) {
+			return fmt.Errorf("criticality override[%d] has unknown changeType:%s", i, override.ChangeType)
+		}
+		switch override.Criticality {
+		case Breaking, Dangerous, NonBreaking:
+		default:
+			return fmt.Errorf("criticality override[%d] has criticality:%s, expected one of: Breaking, Dangerous, NonBreaking", i, override.Criticality)
+		}
+		if _, err := path.Match(override.Path, ""); err != nil {
+			return fmt.Errorf("criticality override[%d] has invalid path glob:%s, error:%v", i, override.Path, err)
+		}
+	}
+	return nil
+}
+
+// applyCriticalityOverrides sets the criticality of the last override matching each change
+func applyCriticalityOverrides(changes []*Change, overrides []CriticalityOverride) {
+	if len(overrides) == 0 {
+		return
+	}
+	for _, change := range changes {
+		for _, override := range overrides {
+			if override.matches(change) {
+				change.criticalityLevel = override.Criticality
+			}
+		}
+	}
+}
+
+func (o CriticalityOverride) matches(change *Change) bool {
+	if o.ChangeType != change.changeType {
+		return false
+	}
+	if len(o.Directive) != 0 && strings.TrimPrefix(o.Directive, "@") != change.directive {
+		return false
+	}
+	if len(o.Path) != 0 {
+		// `*` matches any characters including the dots separating the path elements
+		if matched, err := path.Match(o.Path, change.path); err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+package compare
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestCompareCriticalityOverrides(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldSchema   string
+		newSchema   string
+		overrides   []CriticalityOverride
+		criticality Criticality
+		ChangeType  ChangeType
+	}{
+		{
+			name:        "Enum value added without overrides",
+			oldSchema:   `enum Status { AVAILABLE }`,
+			newSchema:   `enum Status { AVAILABLE LENT }`,
+			criticality: Dangerous,
+			ChangeType:  EnumValueAdded,
+		},
+		{
+			name:      "Enum value added overridden by change type",
+			oldSchema: `enum Status { AVAILABLE }`,
+			newSchema: `enum Status { AVAILABLE LENT }`,
+			overrides: []CriticalityOverride{
+				{ChangeType: EnumValueAdded, Criticality: NonBreaking},
+			},
+			criticality: NonBreaking,
+			ChangeType:  EnumValueAdded,
+		},
+		{
+			name:      "Enum value added overridden by path glob",
+			oldSchema: `enum Status { AVAILABLE }`,
+			newSchema: `enum Status { AVAILABLE LENT }`,
+			overrides: []CriticalityOverride{
+				{ChangeType: EnumValueAdded, Path: "Status.*", Criticality: NonBreaking},
+			},
+			criticality: NonBreaking,
+			ChangeType:  EnumValueAdded,
+		},
+		{
+			name:      "Enum value added not matching the path glob",
+			oldSchema: `enum Status { AVAILABLE }`,
+			newSchema: `enum Status { AVAILABLE LENT }`,
+			overrides: []CriticalityOverride{
+				{ChangeType: EnumValueAdded, Path: "Role.*", Criticality: NonBreaking},
+			},
+			criticality: Dangerous,
+			ChangeType:  EnumValueAdded,
+		},
+		{
+			name:      "Directive removed from a field overridden by directive name",
+			oldSchema: `type Query { books: [String] @auth(role: "reader") }`,
+			newSchema: `type Query { books: [String] }`,
+			overrides: []CriticalityOverride{
+				{ChangeType: DirectiveRemoved, Directive: "@auth", Criticality: Breaking},
+			},
+			criticality: Breaking,
+			ChangeType:  DirectiveRemoved,
+		},
+		{
+			name:      "Directive removed from a field not matching the directive name",
+			oldSchema: `type Query { books: [String] @cacheControl(maxAge: 10) }`,
+			newSchema: `type Query { books: [String] }`,
+			overrides: []CriticalityOverride{
+				{ChangeType: DirectiveRemoved, Directive: "auth", Criticality: Breaking},
+			},
+			criticality: Dangerous,
+			ChangeType:  DirectiveRemoved,
+		},
+		{
+			name:      "Last matching override wins",
+			oldSchema: `enum Status { AVAILABLE }`,
+			newSchema: `enum Status { AVAILABLE LENT }`,
+			overrides: []CriticalityOverride{
+				{ChangeType: EnumValueAdded, Criticality: NonBreaking},
+				{ChangeType: EnumValueAdded, Path: "Status.LENT", Criticality: Breaking},
+			},
+			criticality: Breaking,
+			ChangeType:  EnumValueAdded,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema, WithCriticalityOverrides(tt.overrides))
+			if len(changes) != 1 {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			if changes[0].criticalityLevel != tt.criticality || changes[0].changeType != tt.ChangeType {
+				t.Errorf("Criticality override changes = %v", changes[0])
+			}
+		})
+	}
+}
+
+func TestValidateCriticalityOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides []CriticalityOverride
+		wantErr   bool
+	}{
+		{name: "valid", overrides: []CriticalityOverride{{ChangeType: FieldRemoved, Path: "Query.*"}}},
+		{name: "missing change type", overrides: []CriticalityOverride{{Path: "Query.*"}}, wantErr: true},
+		{name: "invalid glob", overrides: []CriticalityOverride{{ChangeType: FieldRemoved, Path: "Query.[a"}}, wantErr: true},
+		{name: "unknown change type", overrides: []CriticalityOverride{{ChangeType: "ENUM_VALUE_ADED", Criticality: NonBreaking}}, wantErr: true},
+		{name: "SafeUnused criticality", overrides: []CriticalityOverride{{ChangeType: FieldRemoved, Criticality: SafeUnused}}, wantErr: true},
+		{name: "Accepted criticality", overrides: []CriticalityOverride{{ChangeType: FieldRemoved, Criticality: Accepted}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCriticalityOverrides(tt.overrides); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCriticalityOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefaultCriticalityIn(t *testing.T) {
+	tests := []struct {
+		changeType ChangeType
+		context    changeContext
+		want       Criticality
+	}{
+		{changeType: FieldRemoved, context: anyContext, want: Breaking},
+		{changeType: FieldArgumentAdded, context: requiredContext, want: Breaking},
+		{changeType: FieldArgumentAdded, context: optionalContext, want: Dangerous},
+		{changeType: InputFieldTypeChanged, context: compatibleTypeContext, want: NonBreaking},
+		{changeType: InputFieldTypeChanged, context: incompatibleTypeContext, want: Breaking},
+		{changeType: DirectiveRemoved, context: directiveDefinitionContext, want: Breaking},
+		{changeType: DirectiveRemoved, context: directiveUsageContext, want: Dangerous},
+		{changeType: SchemaQueryTypeChanged, context: rootAddedContext, want: NonBreaking},
+		{changeType: FieldExternalRemoved, context: federationV1Context, want: Breaking},
+		{changeType: EnumValueAdded, context: inputEnumContext, want: NonBreaking},
+		{changeType: FieldAdded, context: requiredContext, want: NonBreaking},
+		{changeType: DirectiveArgumentAdded, context: anyContext, want: Breaking},
+		{changeType: "UNKNOWN_CHANGE", context: anyContext, want: Breaking},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(fmt.Sprintf("%s %s", tt.changeType, tt.context), func(t *testing.T) {
+			if got := defaultCriticalityIn(tt.changeType, tt.context); got != tt.want {
+				t.Errorf("defaultCriticalityIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package compare
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// DefaultRemovalArgument is the deprecation directive argument holding the removal date, e.g. @deprecated(removeAfter: "2026-01-01")
+const DefaultRemovalArgument = "removeAfter"
+
+// DeprecationDateArgument is the argument of the policy directive holding the deprecation date, e.g. @deprecated(deprecatedOn: "2025-10-01")
+const DeprecationDateArgument = "deprecatedOn"
+
+var (
+	removalDateRegex     = regexp.MustCompile(`(?i)\bremov\w*(?:\s+(?:on|after|by|from|at))?\s*:?\s*(\d{4}-\d{2}-\d{2})\b`)
+	deprecationDateRegex = regexp.MustCompile(`(?i)\b(?:deprecated(?:\s+(?:on|since|at))?|since)\s*:?\s*(\d{4}-\d{2}-\d{2})\b`)
+	removalVersionRegex  = regexp.MustCompile(`(?i)\bremov\w*\s+(?:in|after|with|from|at)\s+v?(\d+(?:\.\d+)*)\b`)
+)
+
+// DeprecationPolicy is the policy removals of fields, input fields, arguments and enum values have to follow:
+// they have to be deprecated first, and removed after the removal date or version of their deprecation
+type DeprecationPolicy struct {
+	// Directive is the directive holding the removal date, @deprecated when it is empty
+	Directive string
+	// Argument is the argument of the directive holding the removal date, removeAfter when it is empty
+	Argument string
+	// CurrentVersion is the version of the schema which removal versions in deprecation reasons are compared with,
+	// e.g. "Removed in v3.0" can be removed when it is 3.0 or later
+	CurrentVersion string
+	// MinimumWindow is the shortest time allowed between the deprecation date and the removal date, not checked when it is 0.
+	// The deprecation date is read from the deprecatedOn argument of the directive or e.g. "deprecated on 2026-01-01" in the reason
+	MinimumWindow time.Duration
+	// Now returns the current time which removal dates are compared with, time.Now when it is nil
+	Now func() time.Time
+}
+
+// WithDeprecationPolicy checks removals against the deprecation policy. Removals after the removal date or version of their
+// deprecation are downgraded to Dangerous, removals which skipped deprecation or came too early are Breaking even when
+// no client operation uses them.
+func WithDeprecationPolicy(policy DeprecationPolicy) Option {
+	return func(o *options) {
+		o.deprecationPolicy = &policy
+	}
+}
+
+// applyDeprecationPolicy changes the criticality of the removals according to the deprecation policy
+func applyDeprecationPolicy(changes []*Change, oldSchema *ast.SchemaDocument, policy *DeprecationPolicy) {
+	index := newSchemaIndex(oldSchema)
+	now := time.Now()
+	if policy.Now != nil {
+		now = policy.Now()
+	}
+	for _, change := range changes {
+		directives, ok := removedMemberDirectives(change, index)
+		if !ok {
+			continue
+		}
+		deprecation := directives.ForName(deprecatedDirective)
+		if deprecation == nil {
+			change.criticalityLevel = Breaking
+			change.message = fmt.Sprintf("%s without being deprecated first", change.message)
+			continue
+		}
+		removalDate, removalVersion := policy.removal(directives, deprecation)
+		if !removalDate.IsZero() && policy.MinimumWindow > 0 {
+			deprecationDate, ok := policy.deprecationDate(directives, deprecation)
+			if !ok {
+				change.criticalityLevel = Breaking
+				change.message = fmt.Sprintf("%s without a deprecation date to check its deprecation window", change.message)
+				continue
+			}
+			if removalDate.Sub(deprecationDate) < policy.MinimumWindow {
+				change.criticalityLevel = Breaking
+				change.message = fmt.Sprintf("%s with its removal date %s less than %d days after its deprecation on %s", change.message,
+					removalDate.Format("2006-01-02"), int(policy.MinimumWindow.Hours()/24), deprecationDate.Format("2006-01-02"))
+				continue
+			}
+		}
+		switch {
+		case !removalDate.IsZero() && now.After(removalDate):
+			change.message = fmt.Sprintf("%s after its removal date %s", change.message, removalDate.Format("2006-01-02"))
+		case !removalDate.IsZero():
+			change.criticalityLevel = Breaking
+			change.message = fmt.Sprintf("%s before its removal date %s", change.message, removalDate.Format("2006-01-02"))
+			continue
+		case len(removalVersion) != 0 && len(policy.CurrentVersion) != 0 && compareVersions(policy.CurrentVersion, removalVersion) >= 0:
+			change.message = fmt.Sprintf("%s in version %s", change.message, removalVersion)
+		case len(removalVersion) != 0 && len(policy.CurrentVersion) != 0:
+			change.criticalityLevel = Breaking
+			change.message = fmt.Sprintf("%s before its removal version %s", change.message, removalVersion)
+			continue
+		default:
+			change.criticalityLevel = Breaking
+			change.message = fmt.Sprintf("%s without a removal date in its deprecation", change.message)
+			continue
+		}
+		// the removal follows the policy, clients had the deprecation window to migrate
+		if change.criticalityLevel == Breaking {
+			change.criticalityLevel = Dangerous
+		}
+	}
+}
+
+// removedMemberDirectives returns the directives of the field, input field, argument or enum value removed by the change in the old schema
+func removedMemberDirectives(change *Change, index *schemaIndex) (ast.DirectiveList, bool) {
+	parts := strings.Split(change.path, ".")
+	switch change.changeType {
+	case FieldRemoved, InputFieldRemoved, FieldRenamed, EnumValueRemoved, EnumValueRenamed:
+		if len(parts) != 2 {
+			return nil, false
+		}
+		definition, ok := index.types[parts[0]]
+		if !ok {
+			return nil, false
+		}
+		if field := definition.Fields.ForName(parts[1]); field != nil {
+			return field.Directives, true
+		}
+		if enumValue := definition.EnumValues.ForName(parts[1]); enumValue != nil {
+			return enumValue.Directives, true
+		}
+	case FieldArgumentRemoved, ArgumentRenamed:
+		if len(parts) != 3 {
+			return nil, false
+		}
+		definition, ok := index.types[parts[0]]
+		if !ok {
+			return nil, false
+		}
+		if field := definition.Fields.ForName(parts[1]); field != nil {
+			if argument := field.Arguments.ForName(parts[2]); argument != nil {
+				return argument.Directives, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// removal returns the removal date from the policy directive or the deprecation reason, or the removal version from the deprecation reason
+func (p *DeprecationPolicy) removal(directives ast.DirectiveList, deprecation *ast.Directive) (time.Time, string) {
+	directiveName, argumentName := p.Directive, p.Argument
+	if len(directiveName) == 0 {
+		directiveName = deprecatedDirective
+	}
+	if len(argumentName) == 0 {
+		argumentName = DefaultRemovalArgument
+	}
+	if directive := directives.ForName(directiveName); directive != nil {
+		if date, ok := parseRemovalDate(directiveArgument(directive, argumentName)); ok {
+			return date, ""
+		}
+	}
+	reason := directiveArgument(deprecation, "reason")
+	if match := removalDateRegex.FindStringSubmatch(reason); match != nil {
+		if date, ok := parseRemovalDate(match[1]); ok {
+			return date, ""
+		}
+	}
+	if match := removalVersionRegex.FindStringSubmatch(reason); match != nil {
+		return time.Time{}, match[1]
+	}
+	return time.Time{}, ""
+}
+
+// deprecationDate returns the deprecation date from the policy directive or the deprecation reason
+func (p *DeprecationPolicy) deprecationDate(directives ast.DirectiveList, deprecation *ast.Directive) (time.Time, bool) {
+	directiveName := p.Directive
+	if len(directiveName) == 0 {
+		directiveName = deprecatedDirective
+	}
+	if directive := directives.ForName(directiveName); directive != nil {
+		if date, ok := parseRemovalDate(directiveArgument(directive, DeprecationDateArgument)); ok {
+			return date, true
+		}
+	}
+	if match := deprecationDateRegex.FindStringSubmatch(directiveArgument(deprecation, "reason")); match != nil {
+		return parseRemovalDate(match[1])
+	}
+	return time.Time{}, false
+}
+
+func parseRemovalDate(value string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if date, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// compareVersions compares dotted numeric versions, with or without a leading v, missing components are 0
+func compareVersions(a string, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(strings.ToLower(a), "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(strings.ToLower(b), "v"), ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bPart, _ = strconv.Atoi(bParts[i])
+		}
+		if aPart != bPart {
+			if aPart < bPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,335 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestCompareDeprecationPolicy(t *testing.T) {
+	now := func() time.Time {
+		return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name        string
+		oldSchema   string
+		newSchema   string
+		policy      DeprecationPolicy
+		criticality Criticality
+		ChangeType  ChangeType
+		message     string
+	}{
+		{
+			name: "Field removed after the removal date of the directive",
+			oldSchema: `
+			type Book {
+				isbn: String! @deprecated(reason: "use isbn13", removeAfter: "2026-01-01")
+				isbn13: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+			}
+			`,
+			policy:      DeprecationPolicy{Now: now},
+			criticality: Dangerous,
+			ChangeType:  FieldRemoved,
+			message:     "after its removal date 2026-01-01",
+		},
+		{
+			name: "Field removed before the removal date of the directive",
+			oldSchema: `
+			type Book {
+				isbn: String! @deprecated(reason: "use isbn13", removeAfter: "2026-06-01")
+				isbn13: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+			}
+			`,
+			policy:      DeprecationPolicy{Now: now},
+			criticality: Breaking,
+			ChangeType:  FieldRemoved,
+			message:     "before its removal date 2026-06-01",
+		},
+		{
+			name: "Field removed without deprecation",
+			oldSchema: `
+			type Book {
+				isbn: String!
+				isbn13: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+			}
+			`,
+			policy:      DeprecationPolicy{Now: now},
+			criticality: Breaking,
+			ChangeType:  FieldRemoved,
+			message:     "without being deprecated first",
+		},
+		{
+			name: "Field removed after the removal date of the reason",
+			oldSchema: `
+			type Book {
+				isbn: String! @deprecated(reason: "use isbn13, removal after 2026-02-15")
+				isbn13: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+			}
+			`,
+			policy:      DeprecationPolicy{Now: now},
+			criticality: Dangerous,
+			ChangeType:  FieldRemoved,
+		},
+		{
+			name: "Field removed with a date in the reason which isn't a removal date",
+			oldSchema: `
+			type Book {
+				isbn: String! @deprecated(reason: "use isbn13, added on 2025-01-01")
+				isbn13: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+			}
+			`,
+			policy:      DeprecationPolicy{Now: now},
+			criticality: Breaking,
+			ChangeType:  FieldRemoved,
+			message:     "without a removal date",
+		},
+		{
+			name: "Field removed after the deprecation window",
+			oldSchema: `
+			type Book {
+				isbn: String! @deprecated(reason: "use isbn13", deprecatedOn: "2025-09-01", removeAfter: "2026-01-01")
+				isbn13: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+			}
+			`,
+			policy:      DeprecationPolicy{MinimumWindow: 90 * 24 * time.Hour, Now: now},
+			criticality: Dangerous,
+			ChangeType:  FieldRemoved,
+			message:     "after its removal date 2026-01-01",
+		},
+		{
+			name: "Field removed with a removal date inside the deprecation window",
+			oldSchema: `
+			type Book {
+				isbn: String! @deprecated(reason: "use isbn13, deprecated on 2025-12-01, remove after 2026-01-01")
+				isbn13: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+			}
+			`,
+			policy:      DeprecationPolicy{MinimumWindow: 90 * 24 * time.Hour, Now: now},
+			criticality: Breaking,
+			ChangeType:  FieldRemoved,
+			message:     "with its removal date 2026-01-01 less than 90 days after its deprecation on 2025-12-01",
+		},
+		{
+			name: "Field removed without a deprecation date to check the deprecation window",
+			oldSchema: `
+			type Book {
+				isbn: String! @deprecated(reason: "use isbn13", removeAfter: "2026-01-01")
+				isbn13: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+			}
+			`,
+			policy:      DeprecationPolicy{MinimumWindow: 90 * 24 * time.Hour, Now: now},
+			criticality: Breaking,
+			ChangeType:  FieldRemoved,
+			message:     "without a deprecation date",
+		},
+		{
+			name: "Field removed without a removal date",
+			oldSchema: `
+			type Book {
+				isbn: String! @deprecated(reason: "use isbn13")
+				isbn13: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+			}
+			`,
+			policy:      DeprecationPolicy{Now: now},
+			criticality: Breaking,
+			ChangeType:  FieldRemoved,
+			message:     "without a removal date",
+		},
+		{
+			name: "Enum value removed after the removal date of a custom directive",
+			oldSchema: `
+			enum Status {
+				AVAILABLE
+				LENT @deprecated(reason: "use BORROWED") @sunset(date: "2026-02-01")
+				BORROWED
+			}
+			`,
+			newSchema: `
+			enum Status {
+				AVAILABLE
+				BORROWED
+			}
+			`,
+			policy:      DeprecationPolicy{Directive: "sunset", Argument: "date", Now: now},
+			criticality: Dangerous,
+			ChangeType:  EnumValueRemoved,
+		},
+		{
+			name: "Input field removed in the removal version",
+			oldSchema: `
+			input BookInput {
+				isbn: String @deprecated(reason: "Will be removed in v3.0")
+				isbn13: String
+			}
+			`,
+			newSchema: `
+			input BookInput {
+				isbn13: String
+			}
+			`,
+			policy:      DeprecationPolicy{CurrentVersion: "3.0.0", Now: now},
+			criticality: Dangerous,
+			ChangeType:  InputFieldRemoved,
+			message:     "in version 3.0",
+		},
+		{
+			name: "Input field removed before the removal version",
+			oldSchema: `
+			input BookInput {
+				isbn: String @deprecated(reason: "Will be removed in v3.0")
+				isbn13: String
+			}
+			`,
+			newSchema: `
+			input BookInput {
+				isbn13: String
+			}
+			`,
+			policy:      DeprecationPolicy{CurrentVersion: "2.9", Now: now},
+			criticality: Breaking,
+			ChangeType:  InputFieldRemoved,
+			message:     "before its removal version 3.0",
+		},
+		{
+			name: "Argument removed after the removal date",
+			oldSchema: `
+			type Query {
+				books(orderBy: String @deprecated(removeAfter: "2026-01-01"), sortBy: String): [String]
+			}
+			`,
+			newSchema: `
+			type Query {
+				books(sortBy: String): [String]
+			}
+			`,
+			policy:      DeprecationPolicy{Now: now},
+			criticality: Dangerous,
+			ChangeType:  FieldArgumentRemoved,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema, WithDeprecationPolicy(tt.policy))
+			if len(changes) != 1 {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			if changes[0].criticalityLevel != tt.criticality || changes[0].changeType != tt.ChangeType {
+				t.Errorf("Deprecation policy changes = %v", changes[0])
+			}
+			if !strings.Contains(changes[0].message, tt.message) {
+				t.Errorf("message = %v, want it to contain %v", changes[0].message, tt.message)
+			}
+		})
+	}
+}
+
+func TestDeprecationPolicyOverridesOperationUsage(t *testing.T) {
+	oldSchema, err := parser.ParseSchema(&ast.Source{Input: `type Query { books: [String] authors: [String] @deprecated(removeAfter: "2026-01-01") }`})
+	if err != nil {
+		t.Fatalf("error parsing old schema, error = %v", err)
+	}
+	newSchema, err := parser.ParseSchema(&ast.Source{Input: `type Query { other: String }`})
+	if err != nil {
+		t.Fatalf("error parsing new schema, error = %v", err)
+	}
+	operations, err := ParseOperations(map[string][]byte{"op.graphql": []byte(`query Other { other }`)})
+	if err != nil {
+		t.Fatalf("ParseOperations() error = %v", err)
+	}
+	policy := DeprecationPolicy{Now: func() time.Time { return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC) }}
+	for _, change := range FindChangesInSchemas(oldSchema, newSchema, WithOperations(operations), WithDeprecationPolicy(policy)) {
+		switch change.path {
+		case "Query.books":
+			// removed without deprecation, it fails even though no operation uses it
+			if change.criticalityLevel != Breaking {
+				t.Errorf("criticality of %s = %v, want Breaking", change.path, change.criticalityLevel)
+			}
+		case "Query.authors":
+			if change.criticalityLevel != SafeUnused {
+				t.Errorf("criticality of %s = %v, want SafeUnused", change.path, change.criticalityLevel)
+			}
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{name: "equal", a: "3.0", b: "v3.0.0", want: 0},
+		{name: "older", a: "2.9.1", b: "3.0", want: -1},
+		{name: "newer", a: "3.10", b: "3.9", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package compare
+
+import (
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// WithMergedExtensions sets whether every type is folded with all its extensions into one type before comparing, which is the default.
+// When they are merged, moving fields between a type and its extensions, e.g. into another file, isn't reported as a change.
+// When they aren't, types and their extensions are compared separately.
+func WithMergedExtensions(merge bool) Option {
+	return func(o *options) {
+		o.separateExtensions = !merge
+	}
+}
+
+// mergeExtensions returns a copy of the schema where the extensions of the types defined in the schema are folded into
+// their definitions. Extensions of types which aren't defined in the schema, e.g. types of other subgraphs, are folded
+// into one extension. The members keep their own positions, so they still point to the file they live in.
+func mergeExtensions(schema *ast.SchemaDocument) *ast.SchemaDocument {
+	copied := *schema
+	copied.Definitions = make(ast.DefinitionList, 0, len(schema.Definitions))
+	copied.Extensions = ast.DefinitionList{}
+	merged := map[string]*ast.Definition{}
+	for _, definition := range schema.Definitions {
+		if existing, ok := merged[definition.Name]; ok {
+			foldDefinition(existing, definition)
+			continue
+		}
+		copiedDefinition := *definition
+		merged[definition.Name] = &copiedDefinition
+		copied.Definitions = append(copied.Definitions, &copiedDefinition)
+	}
+	extended := map[string]*ast.Definition{}
+	for _, extension := range schema.Extensions {
+		if definition, ok := merged[extension.Name]; ok && definition.Kind == extension.Kind {
+			foldDefinition(definition, extension)
+			continue
+		}
+		if existing, ok := extended[extension.Name]; ok && existing.Kind == extension.Kind {
+			foldDefinition(existing, extension)
+			continue
+		}
+		copiedExtension := *extension
+		extended[extension.Name] = &copiedExtension
+		copied.Extensions = append(copied.Extensions, &copiedExtension)
+	}
+
+	if len(schema.Schema) != 0 {
+		schemaDefinition := *schema.Schema[0]
+		schemaDefinition.Directives = append(ast.DirectiveList{}, schemaDefinition.Directives...)
+		schemaDefinition.OperationTypes = append(ast.OperationTypeDefinitionList{}, schemaDefinition.OperationTypes...)
+		for _, schemaDefinitions := range []ast.SchemaDefinitionList{schema.Schema[1:], schema.SchemaExtension} {
+			for _, extension := range schemaDefinitions {
+				schemaDefinition.Directives = append(schemaDefinition.Directives, extension.Directives...)
+				schemaDefinition.OperationTypes = append(schemaDefinition.OperationTypes, extension.OperationTypes...)
+			}
+		}
+		copied.Schema = ast.SchemaDefinitionList{&schemaDefinition}
+		copied.SchemaExtension = nil
+	}
+	return &copied
+}
+
+// foldDefinition appends the members of the extension to the definition, the member lists are copied so the schema isn't modified
+func foldDefinition(definition *ast.Definition, extension *ast.Definition) {
+	definition.Directives = append(append(ast.DirectiveList{}, definition.Directives...), extension.Directives...)
+	definition.Interfaces = append(append([]string{}, definition.Interfaces...), extension.Interfaces...)
+	definition.Fields = append(append(ast.FieldList{}, definition.Fields...), extension.Fields...)
+	definition.Types = append(append([]string{}, definition.Types...), extension.Types...)
+	definition.EnumValues = append(append(ast.EnumValueList{}, definition.EnumValues...), extension.EnumValues...)
+}
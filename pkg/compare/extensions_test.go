@@ -0,0 +1,126 @@
+package compare
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestCompareMergedExtensions(t *testing.T) {
+	tests := []struct {
+		name         string
+		oldSchema    []string
+		newSchema    []string
+		merge        bool
+		changeTypes  []ChangeType
+		wantPosition string
+	}{
+		{
+			name:      "field moved into an extension in another file",
+			oldSchema: []string{`type Query { books: [String] authors: [String] }`},
+			newSchema: []string{`type Query { books: [String] }`, `extend type Query { authors: [String] }`},
+			merge:     true,
+		},
+		{
+			name:        "field moved into an extension compared separately",
+			oldSchema:   []string{`type Query { books: [String] authors: [String] }`},
+			newSchema:   []string{`type Query { books: [String] }`, `extend type Query { authors: [String] }`},
+			merge:       false,
+			changeTypes: []ChangeType{TypeAdded, FieldRemoved},
+		},
+		{
+			name:         "field added in an extension is reported in the extension file",
+			oldSchema:    []string{`type Query { books: [String] }`, `extend type Query { authors: [String] }`},
+			newSchema:    []string{`type Query { books: [String] }`, `extend type Query { authors: [String] shelves: [String] }`},
+			merge:        true,
+			changeTypes:  []ChangeType{FieldAdded},
+			wantPosition: "1.graphql:1",
+		},
+		{
+			name:        "field removed from an extension",
+			oldSchema:   []string{`type Query { books: [String] }`, `extend type Query { authors: [String] }`},
+			newSchema:   []string{`type Query { books: [String] }`},
+			merge:       true,
+			changeTypes: []ChangeType{FieldRemoved},
+		},
+		{
+			name:      "enum values, union members and interfaces moved into extensions",
+			oldSchema: []string{`enum Status { AVAILABLE LENT } union Media = Book | Film interface Node { id: ID } type Book implements Node { id: ID } type Film { id: ID }`},
+			newSchema: []string{
+				`enum Status { AVAILABLE } union Media = Book type Book { id: ID } type Film { id: ID } interface Node { id: ID }`,
+				`extend enum Status { LENT } extend union Media = Film extend type Book implements Node`,
+			},
+			merge: true,
+		},
+		{
+			name:      "type directives moved into an extension",
+			oldSchema: []string{`type Book @cacheControl(maxAge: 10) { id: ID }`},
+			newSchema: []string{`type Book { id: ID }`, `extend type Book @cacheControl(maxAge: 10)`},
+			merge:     true,
+		},
+		{
+			name:      "extensions of types defined in other subgraphs are merged together",
+			oldSchema: []string{`extend type Book { id: ID title: String }`},
+			newSchema: []string{`extend type Book { id: ID }`, `extend type Book { title: String }`},
+			merge:     true,
+		},
+		{
+			name:      "schema root operation moved into a schema extension",
+			oldSchema: []string{`schema { query: Query mutation: Mutation } type Query { a: Int } type Mutation { a: Int }`},
+			newSchema: []string{`schema { query: Query } type Query { a: Int } type Mutation { a: Int }`, `extend schema { mutation: Mutation }`},
+			merge:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchemas(sources("old", tt.oldSchema)...)
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchemas(sources("new", tt.newSchema)...)
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema, WithMergedExtensions(tt.merge))
+			if len(changes) != len(tt.changeTypes) {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			for i, changeType := range tt.changeTypes {
+				if changes[i].changeType != changeType {
+					t.Errorf("change type = %v, want %v", changes[i].changeType, changeType)
+				}
+			}
+			if len(tt.wantPosition) != 0 {
+				if pos := getPosition(changes[0]); pos != "new"+tt.wantPosition {
+					t.Errorf("change position = %v, want %v", pos, "new"+tt.wantPosition)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeExtensionsDoesNotModifySchema(t *testing.T) {
+	schema, err := parser.ParseSchemas(sources("schema", []string{`type Query { books: [String] }`, `extend type Query { authors: [String] }`})...)
+	if err != nil {
+		t.Fatalf("error parsing schema, error = %v", err)
+	}
+	merged := mergeExtensions(schema)
+	if len(merged.Definitions) != 1 || len(merged.Definitions[0].Fields) != 2 || len(merged.Extensions) != 0 {
+		t.Errorf("mergeExtensions() = %v", merged.Definitions)
+	}
+	if len(schema.Definitions[0].Fields) != 1 || len(schema.Extensions) != 1 {
+		t.Errorf("mergeExtensions() modified the schema")
+	}
+}
+
+// sources returns a source for every schema file, named with the prefix and the index of the file
+func sources(prefix string, inputs []string) []*ast.Source {
+	var sources []*ast.Source
+	for i, input := range inputs {
+		sources = append(sources, &ast.Source{Name: fmt.Sprintf("%s%d.graphql", prefix, i), Input: input})
+	}
+	return sources
+}
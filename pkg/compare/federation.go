@@ -0,0 +1,434 @@
+package compare
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// FederationVersion is the version of Apollo Federation the subgraph schemas are written for
+type FederationVersion string
+
+const (
+	// FederationV1 Apollo Federation 1
+	FederationV1 FederationVersion = "v1"
+	// FederationV2 Apollo Federation 2
+	FederationV2 FederationVersion = "v2"
+)
+
+const (
+	keyDirective       = "key"
+	externalDirective  = "external"
+	requiresDirective  = "requires"
+	providesDirective  = "provides"
+	shareableDirective = "shareable"
+	overrideDirective  = "override"
+)
+
+// ParseFederationVersion returns the federation version for its name
+func ParseFederationVersion(name string) (FederationVersion, error) {
+	for _, version := range []FederationVersion{FederationV1, FederationV2} {
+		if strings.EqualFold(name, string(version)) {
+			return version, nil
+		}
+	}
+	return "", fmt.Errorf("invalid federation version[%s], expected one of: v1, v2", name)
+}
+
+// WithFederation compares the federation directives of the subgraph schemas with the rules of the federation version,
+// e.g. changing the fields of an entity @key is a breaking change
+func WithFederation(version FederationVersion) Option {
+	return func(o *options) {
+		o.federation = version
+	}
+}
+
+// federationDirectives are the directives compared by the federation rules, @shareable and @override are federation 2 only
+func federationDirectives(version FederationVersion) []string {
+	directives := []string{keyDirective, externalDirective, requiresDirective, providesDirective}
+	if version == FederationV2 {
+		directives = append(directives, shareableDirective, overrideDirective)
+	}
+	return directives
+}
+
+// withoutDirectives returns a copy of the schema without the directives applied on types and fields
+func withoutDirectives(schema *ast.SchemaDocument, names []string) *ast.SchemaDocument {
+	copied := *schema
+	copied.Definitions = definitionsWithoutDirectives(schema.Definitions, names)
+	copied.Extensions = definitionsWithoutDirectives(schema.Extensions, names)
+	return &copied
+}
+
+func definitionsWithoutDirectives(definitions ast.DefinitionList, names []string) ast.DefinitionList {
+	copiedDefinitions := make(ast.DefinitionList, 0, len(definitions))
+	for _, definition := range definitions {
+		copiedDefinition := *definition
+		copiedDefinition.Directives = directivesWithout(definition.Directives, names)
+		copiedDefinition.Fields = make(ast.FieldList, 0, len(definition.Fields))
+		for _, field := range definition.Fields {
+			copiedField := *field
+			copiedField.Directives = directivesWithout(field.Directives, names)
+			copiedDefinition.Fields = append(copiedDefinition.Fields, &copiedField)
+		}
+		copiedDefinitions = append(copiedDefinitions, &copiedDefinition)
+	}
+	return copiedDefinitions
+}
+
+func directivesWithout(directives ast.DirectiveList, names []string) ast.DirectiveList {
+	filtered := ast.DirectiveList{}
+	for _, directive := range directives {
+		if !containsString(names, directive.Name) {
+			filtered = append(filtered, directive)
+		}
+	}
+	return filtered
+}
+
+// changeInFederation changes in the entities and the federation directives of the fields
+func changeInFederation(oldSchema *ast.SchemaDocument, newSchema *ast.SchemaDocument, version FederationVersion) []*Change {
+	var changes []*Change
+	oldTypes := newSchemaIndex(oldSchema).types
+	newTypes := newSchemaIndex(newSchema).types
+	typeNames := make([]string, 0, len(oldTypes))
+	for name := range oldTypes {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+	for _, name := range typeNames {
+		ot, nt := oldTypes[name], newTypes[name]
+		if nt == nil || (ot.Kind != ast.Object && ot.Kind != ast.Interface) || ot.Kind != nt.Kind {
+			// removed types and kind changes are reported by the type rules
+			continue
+		}
+		changes = append(changes, changeInEntityKeys(ot, nt)...)
+		if version == FederationV2 {
+			changes = append(changes, checkShareableChanged(ot.Directives, nt.Directives, nt.Name, nt.Position)...)
+		}
+		for _, of := range ot.Fields {
+			nf := nt.Fields.ForName(of.Name)
+			if nf == nil {
+				continue
+			}
+			changes = append(changes, changeInFieldFederation(nt, of, nf, version)...)
+		}
+	}
+	return changes
+}
+
+// changeInEntityKeys compares the @key field sets of the type, keys are matched by their normalized field sets
+func changeInEntityKeys(ot *ast.Definition, nt *ast.Definition) []*Change {
+	var changes []*Change
+	oldKeys := entityKeys(ot)
+	newKeys := entityKeys(nt)
+	if len(oldKeys) == 0 {
+		for _, key := range newKeys {
+			changes = append(changes, &Change{
+				changeType:       EntityKeyAdded,
+				criticalityLevel: defaultCriticality(EntityKeyAdded),
+				message:          fmt.Sprintf("Key '%s' was added to entity '%s'", key.fields, nt.Name),
+				path:             nt.Name,
+				position:         key.position,
+				newPosition:      key.position,
+			})
+		}
+		return changes
+	}
+	if len(newKeys) == 0 {
+		//the type can't be resolved by other subgraphs anymore
+		return append(changes, &Change{
+			changeType:       EntityRemoved,
+			criticalityLevel: defaultCriticality(EntityRemoved),
+			message:          fmt.Sprintf("Type '%s' is no longer an entity, all its keys were removed", nt.Name),
+			path:             nt.Name,
+			position:         nt.Position,
+			oldPosition:      ot.Position,
+			newPosition:      nt.Position,
+		})
+	}
+
+	var removed, added []entityKey
+	for _, key := range oldKeys {
+		if !containsKey(newKeys, key) {
+			removed = append(removed, key)
+		}
+	}
+	for _, key := range newKeys {
+		if !containsKey(oldKeys, key) {
+			added = append(added, key)
+		}
+	}
+	if len(removed) == 1 && len(added) == 1 {
+		//query plans of other subgraphs reference the entity by the old key fields
+		return append(changes, &Change{
+			changeType:       EntityKeyChanged,
+			criticalityLevel: defaultCriticality(EntityKeyChanged),
+			message:          fmt.Sprintf("Key of entity '%s' changed from '%s' to '%s'", nt.Name, removed[0].fields, added[0].fields),
+			path:             nt.Name,
+			position:         added[0].position,
+			oldPosition:      removed[0].position,
+			newPosition:      added[0].position,
+		})
+	}
+	for _, key := range removed {
+		changes = append(changes, &Change{
+			changeType:       EntityKeyRemoved,
+			criticalityLevel: defaultCriticality(EntityKeyRemoved),
+			message:          fmt.Sprintf("Key '%s' was removed from entity '%s'", key.fields, nt.Name),
+			path:             nt.Name,
+			position:         nt.Position,
+			oldPosition:      key.position,
+		})
+	}
+	for _, key := range added {
+		changes = append(changes, &Change{
+			changeType:       EntityKeyAdded,
+			criticalityLevel: defaultCriticality(EntityKeyAdded),
+			message:          fmt.Sprintf("Key '%s' was added to entity '%s'", key.fields, nt.Name),
+			path:             nt.Name,
+			position:         key.position,
+			newPosition:      key.position,
+		})
+	}
+	return changes
+}
+
+type entityKey struct {
+	fields   string
+	position *ast.Position
+}
+
+// entityKeys returns the normalized field sets of the @key directives, keys which can't be resolved by the subgraph are marked
+func entityKeys(definition *ast.Definition) []entityKey {
+	var keys []entityKey
+	for _, directive := range definition.Directives.ForNames(keyDirective) {
+		fields := normalizeFieldSet(directiveArgument(directive, "fields"))
+		if resolvable := directive.Arguments.ForName("resolvable"); resolvable != nil && resolvable.Value.Raw == "false" {
+			fields = fmt.Sprintf("%s (resolvable: false)", fields)
+		}
+		keys = append(keys, entityKey{fields: fields, position: directive.Position})
+	}
+	return keys
+}
+
+func containsKey(keys []entityKey, key entityKey) bool {
+	for _, k := range keys {
+		if k.fields == key.fields {
+			return true
+		}
+	}
+	return false
+}
+
+// changeInFieldFederation compares the federation directives of a field
+func changeInFieldFederation(nt *ast.Definition, of *ast.FieldDefinition, nf *ast.FieldDefinition, version FederationVersion) []*Change {
+	var changes []*Change
+	path := fmt.Sprintf("%s.%s", nt.Name, nf.Name)
+	oExternal := of.Directives.ForName(externalDirective)
+	nExternal := nf.Directives.ForName(externalDirective)
+	switch {
+	case oExternal == nil && nExternal != nil:
+		//the subgraph stops resolving the field, query plans fetching it from the subgraph break
+		changes = append(changes, &Change{
+			changeType:       FieldExternalAdded,
+			criticalityLevel: defaultCriticality(FieldExternalAdded),
+			message:          fmt.Sprintf("Field '%s' was marked @external, it is no longer resolved by the subgraph", path),
+			path:             path,
+			position:         nExternal.Position,
+			newPosition:      nExternal.Position,
+		})
+	case oExternal != nil && nExternal == nil:
+		//federation 1 doesn't allow more than one subgraph to resolve a field, federation 2 requires it to be @shareable
+		federationContext := federationV2Context
+		if version == FederationV1 {
+			federationContext = federationV1Context
+		}
+		changes = append(changes, &Change{
+			changeType:       FieldExternalRemoved,
+			criticalityLevel: defaultCriticalityIn(FieldExternalRemoved, federationContext),
+			message:          fmt.Sprintf("Field '%s' is no longer @external, it is resolved by the subgraph", path),
+			path:             path,
+			position:         nf.Position,
+			oldPosition:      oExternal.Position,
+		})
+	}
+
+	changes = append(changes, checkFieldSetChanged(of, nf, path, requiresDirective, fieldSetChangeTypes{
+		added: FieldRequiresAdded, removed: FieldRequiresRemoved, changed: FieldRequiresChanged,
+	})...)
+	changes = append(changes, checkFieldSetChanged(of, nf, path, providesDirective, fieldSetChangeTypes{
+		added: FieldProvidesAdded, removed: FieldProvidesRemoved, changed: FieldProvidesChanged,
+	})...)
+
+	if version == FederationV2 {
+		changes = append(changes, checkShareableChanged(of.Directives, nf.Directives, path, nf.Position)...)
+		changes = append(changes, checkOverrideChanged(of, nf, path)...)
+	}
+	return changes
+}
+
+// fieldSetChangeTypes are the change types of a directive with a field set argument
+type fieldSetChangeTypes struct {
+	added, removed, changed ChangeType
+}
+
+// checkFieldSetChanged compares the field sets of the @requires or @provides directive of a field
+func checkFieldSetChanged(of *ast.FieldDefinition, nf *ast.FieldDefinition, path string, name string, types fieldSetChangeTypes) []*Change {
+	od := of.Directives.ForName(name)
+	nd := nf.Directives.ForName(name)
+	switch {
+	case od == nil && nd != nil:
+		return []*Change{{
+			changeType:       types.added,
+			criticalityLevel: defaultCriticality(types.added),
+			message:          fmt.Sprintf("Directive '@%s(fields: \"%s\")' was added to field '%s'", name, normalizeFieldSet(directiveArgument(nd, "fields")), path),
+			path:             path,
+			position:         nd.Position,
+			newPosition:      nd.Position,
+		}}
+	case od != nil && nd == nil:
+		return []*Change{{
+			changeType:       types.removed,
+			criticalityLevel: defaultCriticality(types.removed),
+			message:          fmt.Sprintf("Directive '@%s(fields: \"%s\")' was removed from field '%s'", name, normalizeFieldSet(directiveArgument(od, "fields")), path),
+			path:             path,
+			position:         nf.Position,
+			oldPosition:      od.Position,
+		}}
+	case od != nil && nd != nil:
+		oldFields := normalizeFieldSet(directiveArgument(od, "fields"))
+		newFields := normalizeFieldSet(directiveArgument(nd, "fields"))
+		if oldFields != newFields {
+			return []*Change{{
+				changeType:       types.changed,
+				criticalityLevel: defaultCriticality(types.changed),
+				message:          fmt.Sprintf("Fields of directive '@%s' changed from '%s' to '%s' on field '%s'", name, oldFields, newFields, path),
+				path:             path,
+				position:         nd.Position,
+				oldPosition:      od.Position,
+				newPosition:      nd.Position,
+			}}
+		}
+	}
+	return nil
+}
+
+// checkShareableChanged compares @shareable on a type or a field
+func checkShareableChanged(oDirs ast.DirectiveList, nDirs ast.DirectiveList, path string, pos *ast.Position) []*Change {
+	od := oDirs.ForName(shareableDirective)
+	nd := nDirs.ForName(shareableDirective)
+	if od == nil && nd != nil {
+		return []*Change{{
+			changeType:       ShareableAdded,
+			criticalityLevel: defaultCriticality(ShareableAdded),
+			message:          fmt.Sprintf("'%s' was marked @shareable", path),
+			path:             path,
+			position:         nd.Position,
+			newPosition:      nd.Position,
+		}}
+	}
+	if od != nil && nd == nil {
+		//composition fails when other subgraphs resolve the same fields
+		return []*Change{{
+			changeType:       ShareableRemoved,
+			criticalityLevel: defaultCriticality(ShareableRemoved),
+			message:          fmt.Sprintf("'%s' is no longer @shareable", path),
+			path:             path,
+			position:         pos,
+			oldPosition:      od.Position,
+		}}
+	}
+	return nil
+}
+
+// checkOverrideChanged compares @override on a field, which migrates resolving the field from another subgraph
+func checkOverrideChanged(of *ast.FieldDefinition, nf *ast.FieldDefinition, path string) []*Change {
+	od := of.Directives.ForName(overrideDirective)
+	nd := nf.Directives.ForName(overrideDirective)
+	switch {
+	case od == nil && nd != nil:
+		return []*Change{{
+			changeType:       FieldOverrideAdded,
+			criticalityLevel: defaultCriticality(FieldOverrideAdded),
+			message:          fmt.Sprintf("Field '%s' now overrides subgraph '%s'", path, directiveArgument(nd, "from")),
+			path:             path,
+			position:         nd.Position,
+			newPosition:      nd.Position,
+		}}
+	case od != nil && nd == nil:
+		return []*Change{{
+			changeType:       FieldOverrideRemoved,
+			criticalityLevel: defaultCriticality(FieldOverrideRemoved),
+			message:          fmt.Sprintf("Field '%s' no longer overrides subgraph '%s'", path, directiveArgument(od, "from")),
+			path:             path,
+			position:         nf.Position,
+			oldPosition:      od.Position,
+		}}
+	case od != nil && nd != nil:
+		oldOverride := fmt.Sprintf("%s %s", directiveArgument(od, "from"), directiveArgument(od, "label"))
+		newOverride := fmt.Sprintf("%s %s", directiveArgument(nd, "from"), directiveArgument(nd, "label"))
+		if oldOverride != newOverride {
+			return []*Change{{
+				changeType:       FieldOverrideChanged,
+				criticalityLevel: defaultCriticality(FieldOverrideChanged),
+				message:          fmt.Sprintf("Override of field '%s' changed from '%s' to '%s'", path, strings.TrimSpace(oldOverride), strings.TrimSpace(newOverride)),
+				path:             path,
+				position:         nd.Position,
+				oldPosition:      od.Position,
+				newPosition:      nd.Position,
+			}}
+		}
+	}
+	return nil
+}
+
+// directiveArgument returns the raw value of the directive argument, or an empty string when it isn't passed
+func directiveArgument(directive *ast.Directive, name string) string {
+	if argument := directive.Arguments.ForName(name); argument != nil && argument.Value != nil {
+		return argument.Value.Raw
+	}
+	return ""
+}
+
+// normalizeFieldSet parses the field set as a selection set and prints it with the fields sorted,
+// so that field sets selecting the same fields are equal. Field sets which can't be parsed are only trimmed.
+func normalizeFieldSet(fields string) string {
+	doc, err := parser.ParseQuery(&ast.Source{Input: fmt.Sprintf("{%s}", fields)})
+	if err != nil || len(doc.Operations) != 1 {
+		return strings.Join(strings.Fields(fields), " ")
+	}
+	return printSelectionSet(doc.Operations[0].SelectionSet)
+}
+
+func printSelectionSet(selectionSet ast.SelectionSet) string {
+	selections := make([]string, 0, len(selectionSet))
+	for _, selection := range selectionSet {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			printed := selection.Name
+			if len(selection.SelectionSet) != 0 {
+				printed = fmt.Sprintf("%s { %s }", printed, printSelectionSet(selection.SelectionSet))
+			}
+			selections = append(selections, printed)
+		case *ast.InlineFragment:
+			selections = append(selections, fmt.Sprintf("... on %s { %s }", selection.TypeCondition, printSelectionSet(selection.SelectionSet)))
+		case *ast.FragmentSpread:
+			selections = append(selections, fmt.Sprintf("...%s", selection.Name))
+		}
+	}
+	sort.Strings(selections)
+	return strings.Join(selections, " ")
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,536 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestCompareFederation(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     FederationVersion
+		oldSchema   string
+		newSchema   string
+		criticality Criticality
+		ChangeType  ChangeType
+	}{
+		{
+			name:    "Entity key fields changed",
+			version: FederationV1,
+			oldSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "isbn title") {
+				isbn: String!
+				title: String
+			}
+			`,
+			criticality: Breaking,
+			ChangeType:  EntityKeyChanged,
+		},
+		{
+			name:    "Entity key fields changed in extended type",
+			version: FederationV1,
+			oldSchema: `
+			extend type Book @key(fields: "isbn") {
+				isbn: String! @external
+			}
+			`,
+			newSchema: `
+			extend type Book @key(fields: "id") {
+				isbn: String! @external
+			}
+			`,
+			criticality: Breaking,
+			ChangeType:  EntityKeyChanged,
+		},
+		{
+			name:    "Entity key field set reordered",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "isbn author { id name }") {
+				isbn: String!
+				author: Author
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "author { name id }   isbn") {
+				isbn: String!
+				author: Author
+			}
+			`,
+		},
+		{
+			name:    "Entity key made not resolvable",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "isbn", resolvable: false) {
+				isbn: String!
+			}
+			`,
+			criticality: Breaking,
+			ChangeType:  EntityKeyChanged,
+		},
+		{
+			name:    "One of the entity keys removed",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "isbn") @key(fields: "id") {
+				id: ID!
+				isbn: String!
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "id") {
+				id: ID!
+				isbn: String!
+			}
+			`,
+			criticality: Breaking,
+			ChangeType:  EntityKeyRemoved,
+		},
+		{
+			name:    "Entity key added",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "id") {
+				id: ID!
+				isbn: String!
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "id") @key(fields: "isbn") {
+				id: ID!
+				isbn: String!
+			}
+			`,
+			criticality: NonBreaking,
+			ChangeType:  EntityKeyAdded,
+		},
+		{
+			name:    "Type made an entity",
+			version: FederationV1,
+			oldSchema: `
+			type Book {
+				isbn: String!
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+			}
+			`,
+			criticality: NonBreaking,
+			ChangeType:  EntityKeyAdded,
+		},
+		{
+			name:    "Entity removed",
+			version: FederationV1,
+			oldSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn: String!
+			}
+			`,
+			criticality: Breaking,
+			ChangeType:  EntityRemoved,
+		},
+		{
+			name:    "Field external added",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String @external
+			}
+			`,
+			criticality: Breaking,
+			ChangeType:  FieldExternalAdded,
+		},
+		{
+			name:    "Field external removed in federation 1",
+			version: FederationV1,
+			oldSchema: `
+			extend type Book @key(fields: "isbn") {
+				isbn: String! @external
+			}
+			`,
+			newSchema: `
+			extend type Book @key(fields: "isbn") {
+				isbn: String!
+			}
+			`,
+			criticality: Breaking,
+			ChangeType:  FieldExternalRemoved,
+		},
+		{
+			name:    "Field external removed in federation 2",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String @external
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String
+			}
+			`,
+			criticality: Dangerous,
+			ChangeType:  FieldExternalRemoved,
+		},
+		{
+			name:    "Field requires added",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				weight: Int @external
+				shipping: Int
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				weight: Int @external
+				shipping: Int @requires(fields: "weight")
+			}
+			`,
+			criticality: Dangerous,
+			ChangeType:  FieldRequiresAdded,
+		},
+		{
+			name:    "Field requires removed",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				weight: Int @external
+				shipping: Int @requires(fields: "weight")
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				weight: Int @external
+				shipping: Int
+			}
+			`,
+			criticality: NonBreaking,
+			ChangeType:  FieldRequiresRemoved,
+		},
+		{
+			name:    "Field requires fields changed",
+			version: FederationV1,
+			oldSchema: `
+			extend type Book @key(fields: "isbn") {
+				isbn: String! @external
+				weight: Int @external
+				size: Int @external
+				shipping: Int @requires(fields: "weight")
+			}
+			`,
+			newSchema: `
+			extend type Book @key(fields: "isbn") {
+				isbn: String! @external
+				weight: Int @external
+				size: Int @external
+				shipping: Int @requires(fields: "weight size")
+			}
+			`,
+			criticality: Dangerous,
+			ChangeType:  FieldRequiresChanged,
+		},
+		{
+			name:    "Field provides removed",
+			version: FederationV2,
+			oldSchema: `
+			type Review {
+				book: Book @provides(fields: "title")
+			}
+			`,
+			newSchema: `
+			type Review {
+				book: Book
+			}
+			`,
+			criticality: Dangerous,
+			ChangeType:  FieldProvidesRemoved,
+		},
+		{
+			name:    "Field provides fields changed",
+			version: FederationV2,
+			oldSchema: `
+			type Review {
+				book: Book @provides(fields: "title")
+			}
+			`,
+			newSchema: `
+			type Review {
+				book: Book @provides(fields: "title year")
+			}
+			`,
+			criticality: Dangerous,
+			ChangeType:  FieldProvidesChanged,
+		},
+		{
+			name:    "Field provides added",
+			version: FederationV2,
+			oldSchema: `
+			type Review {
+				book: Book
+			}
+			`,
+			newSchema: `
+			type Review {
+				book: Book @provides(fields: "title")
+			}
+			`,
+			criticality: NonBreaking,
+			ChangeType:  FieldProvidesAdded,
+		},
+		{
+			name:    "Type shareable removed",
+			version: FederationV2,
+			oldSchema: `
+			type Position @shareable {
+				x: Int
+			}
+			`,
+			newSchema: `
+			type Position {
+				x: Int
+			}
+			`,
+			criticality: Breaking,
+			ChangeType:  ShareableRemoved,
+		},
+		{
+			name:    "Field shareable added",
+			version: FederationV2,
+			oldSchema: `
+			type Position {
+				x: Int
+			}
+			`,
+			newSchema: `
+			type Position {
+				x: Int @shareable
+			}
+			`,
+			criticality: NonBreaking,
+			ChangeType:  ShareableAdded,
+		},
+		{
+			name:    "Field override added",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String @override(from: "catalog")
+			}
+			`,
+			criticality: Dangerous,
+			ChangeType:  FieldOverrideAdded,
+		},
+		{
+			name:    "Field override removed",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String @override(from: "catalog")
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String
+			}
+			`,
+			criticality: Dangerous,
+			ChangeType:  FieldOverrideRemoved,
+		},
+		{
+			name:    "Field override label changed",
+			version: FederationV2,
+			oldSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String @override(from: "catalog", label: "percent(10)")
+			}
+			`,
+			newSchema: `
+			type Book @key(fields: "isbn") {
+				isbn: String!
+				title: String @override(from: "catalog", label: "percent(50)")
+			}
+			`,
+			criticality: Dangerous,
+			ChangeType:  FieldOverrideChanged,
+		},
+		{
+			name:    "Shareable is a plain directive in federation 1",
+			version: FederationV1,
+			oldSchema: `
+			type Position @shareable {
+				x: Int
+			}
+			`,
+			newSchema: `
+			type Position {
+				x: Int
+			}
+			`,
+			criticality: Dangerous,
+			ChangeType:  DirectiveRemoved,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema, WithFederation(tt.version))
+			if len(tt.ChangeType) == 0 {
+				if len(changes) != 0 {
+					t.Errorf("Unexpected changes added = %v", changes)
+				}
+				return
+			}
+			if len(changes) != 1 {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			if changes[0].criticalityLevel != tt.criticality || changes[0].changeType != tt.ChangeType {
+				t.Errorf("Federation changes = %v", changes[0])
+			}
+		})
+	}
+}
+
+func TestCompareFederationDirectivesWithoutFederation(t *testing.T) {
+	oldSchema, err := parser.ParseSchema(&ast.Source{
+		Input: `type Book @key(fields: "isbn") { isbn: String! }`,
+	})
+	if err != nil {
+		t.Fatalf("error parsing old schema, error = %v", err)
+	}
+	newSchema, err := parser.ParseSchema(&ast.Source{
+		Input: `type Book { isbn: String! }`,
+	})
+	if err != nil {
+		t.Fatalf("error parsing new schema, error = %v", err)
+	}
+	changes := FindChangesInSchemas(oldSchema, newSchema)
+	if len(changes) != 1 || changes[0].changeType != DirectiveRemoved || changes[0].criticalityLevel != Dangerous {
+		t.Errorf("Unexpected changes = %v", changes)
+	}
+	if len(oldSchema.Definitions[0].Directives) != 1 {
+		t.Errorf("FindChangesInSchemas() modified the schema")
+	}
+}
+
+func TestNormalizeFieldSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{
+			name:   "single field",
+			fields: "isbn",
+			want:   "isbn",
+		},
+		{
+			name:   "fields are sorted",
+			fields: "title  isbn",
+			want:   "isbn title",
+		},
+		{
+			name:   "nested selection sets are sorted",
+			fields: "isbn author { name id }",
+			want:   "author { id name } isbn",
+		},
+		{
+			name:   "inline fragments",
+			fields: "media { ... on Book { isbn } }",
+			want:   "media { ... on Book { isbn } }",
+		},
+		{
+			name:   "invalid field set is only trimmed",
+			fields: " isbn { ",
+			want:   "isbn {",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeFieldSet(tt.fields); got != tt.want {
+				t.Errorf("normalizeFieldSet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFederationVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    FederationVersion
+		wantErr bool
+	}{
+		{name: "v1", version: "v1", want: FederationV1},
+		{name: "v2 case insensitive", version: "V2", want: FederationV2},
+		{name: "invalid", version: "v3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFederationVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFederationVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFederationVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package compare
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// JSONReportVersion is the version of the JSON report format. It is bumped whenever a field is removed or its meaning changes.
+const JSONReportVersion = 1
+
+// JSONReport is the machine-readable representation of a schema compare result
+type JSONReport struct {
+	Version int       `json:"version"`
+	Changes []*Change `json:"changes"`
+	// Stale lists the entries of the accepted changes file which no longer accept any change
+	Stale []*StaleAcceptedChange `json:"staleAccepted,omitempty"`
+}
+
+type jsonPosition struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+type jsonChange struct {
+	ChangeType   ChangeType      `json:"changeType"`
+	Criticality  Criticality     `json:"criticality"`
+	Path         string          `json:"path"`
+	Message      string          `json:"message"`
+	Position     *jsonPosition   `json:"position,omitempty"`
+	OldPosition  *jsonPosition   `json:"oldPosition,omitempty"`
+	NewPosition  *jsonPosition   `json:"newPosition,omitempty"`
+	Operations   []string        `json:"operations,omitempty"`
+	Accepted     *AcceptedChange `json:"accepted,omitempty"`
+	AcceptedFrom *Criticality    `json:"acceptedFrom,omitempty"`
+	Diff         *ValueDiff      `json:"diff,omitempty"`
+}
+
+func newJSONPosition(position *ast.Position) *jsonPosition {
+	if position == nil {
+		return nil
+	}
+	jp := &jsonPosition{
+		Line:   position.Line,
+		Column: position.Column,
+	}
+	if position.Src != nil {
+		jp.File = position.Src.Name
+	}
+	return jp
+}
+
+// MarshalJSON encodes the change with its type, criticality, path, message and positions
+func (c *Change) MarshalJSON() ([]byte, error) {
+	jc := jsonChange{
+		ChangeType:  c.changeType,
+		Criticality: c.criticalityLevel,
+		Path:        c.path,
+		Message:     c.message,
+		Operations:  c.operations,
+		Accepted:    c.accepted,
+		Diff:        c.diff,
+		Position:    newJSONPosition(c.position),
+		OldPosition: newJSONPosition(c.oldPosition),
+		NewPosition: newJSONPosition(c.newPosition),
+	}
+	if c.accepted != nil {
+		jc.AcceptedFrom = &c.acceptedFrom
+	}
+	return json.Marshal(jc)
+}
+
+// MarshalJSON encodes the criticality as its name
+func (c Criticality) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// WriteJSONReport writes all the changes as a versioned JSON report, ordered by criticality and then by position,
+// along with the stale entries of the accepted changes file
+func WriteJSONReport(w io.Writer, changes []*Change, stale ...*StaleAcceptedChange) error {
+	sorted := SortChanges(changes)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(JSONReport{
+		Version: JSONReportVersion,
+		Changes: sorted,
+		Stale:   stale,
+	})
+}
+
+// JSONReporter writes the changes as a versioned JSON report, see WriteJSONReport
+type JSONReporter struct {
+	w       io.Writer
+	changes []*Change
+}
+
+// NewJSONReporter creates a JSON reporter writing to w
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{w: w}
+}
+
+// Begin resets the changes of a previous report
+func (r *JSONReporter) Begin() error {
+	r.changes = nil
+	return nil
+}
+
+// Report collects the change, the report is written at the end
+func (r *JSONReporter) Report(change *Change) error {
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+// End writes the report with all the changes and the stale accepted changes
+func (r *JSONReporter) End(stale []*StaleAcceptedChange) error {
+	return WriteJSONReport(r.w, r.changes, stale...)
+}
@@ -0,0 +1,92 @@
+package compare
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestWriteJSONReport(t *testing.T) {
+	oldSchema, err := parser.ParseSchema(&ast.Source{
+		Name: "old.graphql",
+		Input: `
+		type Book {
+			isbn: String!
+			title: String
+		}
+		`,
+	})
+	if err != nil {
+		t.Fatalf("error parsing old schema, error = %v", err)
+	}
+	newSchema, err := parser.ParseSchema(&ast.Source{
+		Name: "new.graphql",
+		Input: `
+		type Book {
+			isbn: String!
+			author: String
+		}
+		`,
+	})
+	if err != nil {
+		t.Fatalf("error parsing new schema, error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSONReport(&buf, FindChangesInSchemas(oldSchema, newSchema)); err != nil {
+		t.Fatalf("WriteJSONReport() error = %v", err)
+	}
+
+	var report struct {
+		Version int `json:"version"`
+		Changes []struct {
+			ChangeType  string `json:"changeType"`
+			Criticality string `json:"criticality"`
+			Path        string `json:"path"`
+			Message     string `json:"message"`
+			Position    struct {
+				File   string `json:"file"`
+				Line   int    `json:"line"`
+				Column int    `json:"column"`
+			} `json:"position"`
+			OldPosition *struct {
+				File string `json:"file"`
+				Line int    `json:"line"`
+			} `json:"oldPosition"`
+			NewPosition *struct {
+				File string `json:"file"`
+				Line int    `json:"line"`
+			} `json:"newPosition"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid json report, error = %v", err)
+	}
+	if report.Version != JSONReportVersion {
+		t.Errorf("report version = %d, want %d", report.Version, JSONReportVersion)
+	}
+	if len(report.Changes) != 2 {
+		t.Fatalf("Unexpected changes in report = %v", report.Changes)
+	}
+	// breaking changes are reported first
+	removed := report.Changes[0]
+	if removed.ChangeType != string(FieldRemoved) || removed.Criticality != "Breaking" || removed.Path != "Book.title" {
+		t.Errorf("Unexpected first change = %+v", removed)
+	}
+	if removed.OldPosition == nil || removed.OldPosition.File != "old.graphql" || removed.OldPosition.Line != 4 || removed.NewPosition != nil {
+		t.Errorf("Unexpected old and new position = %+v %+v", removed.OldPosition, removed.NewPosition)
+	}
+	added := report.Changes[1]
+	if added.ChangeType != string(FieldAdded) || added.Criticality != "NonBreaking" || added.Path != "Book.author" {
+		t.Errorf("Unexpected second change = %+v", added)
+	}
+	if added.Position.File != "new.graphql" || added.Position.Line != 4 || added.Position.Column != 4 {
+		t.Errorf("Unexpected position = %+v", added.Position)
+	}
+	if len(added.Message) == 0 {
+		t.Errorf("Expected message for change = %+v", added)
+	}
+}
@@ -0,0 +1,175 @@
+package compare
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const junitSuitesName = "gql compare"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitReporter writes the changes as a JUnit XML report with a test suite per criticality, so CI systems can show them as tests.
+// Changes of the FailOn criticalities are failed test cases, the other changes are passed ones.
+type JUnitReporter struct {
+	// FailOn are the criticalities of the changes which are reported as failures, Breaking when it is empty
+	FailOn []Criticality
+
+	w       io.Writer
+	changes []*Change
+}
+
+// NewJUnitReporter creates a JUnit reporter writing to w
+func NewJUnitReporter(w io.Writer) *JUnitReporter {
+	return &JUnitReporter{w: w}
+}
+
+// Begin resets the changes of a previous report
+func (r *JUnitReporter) Begin() error {
+	r.changes = nil
+	return nil
+}
+
+// Report collects the change, the report is written at the end
+func (r *JUnitReporter) Report(change *Change) error {
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+// End writes the report, the stale accepted changes are skipped test cases
+func (r *JUnitReporter) End(stale []*StaleAcceptedChange) error {
+	failOn := r.FailOn
+	if len(failOn) == 0 {
+		failOn = []Criticality{Breaking}
+	}
+	report := junitTestSuites{Name: junitSuitesName}
+	groups := GroupChanges(r.changes)
+	for _, criticality := range criticalitiesBySeverity() {
+		changes := groups[criticality]
+		if len(changes) == 0 {
+			continue
+		}
+		suite := junitTestSuite{Name: criticality.String()}
+		for _, c := range changes {
+			testCase := junitTestCase{Name: c.path, ClassName: string(c.changeType)}
+			if position := reportedPosition(c); position != nil {
+				testCase.Line = position.Line
+				if position.Src != nil {
+					testCase.File = position.Src.Name
+				}
+			}
+			if containsCriticality(failOn, criticality) {
+				testCase.Failure = &junitFailure{Message: strings.TrimSpace(c.message), Type: criticality.String(), Text: junitFailureText(c)}
+				suite.Failures++
+			} else {
+				testCase.SystemOut = strings.TrimSpace(c.message)
+				if c.accepted != nil {
+					testCase.SystemOut = fmt.Sprintf("%s\naccepted: %s", testCase.SystemOut, acceptedText(c))
+				}
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suite.Tests = len(suite.Cases)
+		report.Suites = append(report.Suites, suite)
+	}
+	if len(stale) != 0 {
+		suite := junitTestSuite{Name: "StaleAccepted"}
+		for _, s := range stale {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      s.Path,
+				ClassName: string(s.ChangeType),
+				Skipped:   &junitSkipped{Message: fmt.Sprintf("accepted change is stale: %s", s.Reason)},
+			})
+		}
+		suite.Tests = len(suite.Cases)
+		suite.Skipped = len(suite.Cases)
+		report.Suites = append(report.Suites, suite)
+	}
+	for _, suite := range report.Suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+	}
+	if _, err := io.WriteString(r.w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(r.w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(r.w, "\n")
+	return err
+}
+
+// reportedPosition is the position of the changed element in the new schema, or in the old one when it was removed
+func reportedPosition(c *Change) *ast.Position {
+	if c.newPosition != nil {
+		return c.newPosition
+	}
+	if c.oldPosition != nil {
+		return c.oldPosition
+	}
+	return c.position
+}
+
+// junitFailureText describes the failed change with its positions, the operations it breaks and its acceptance
+func junitFailureText(c *Change) string {
+	lines := []string{strings.TrimSpace(c.message)}
+	if positions := getPositions(c); len(positions) != 0 {
+		lines = append(lines, fmt.Sprintf("at: %s", positions))
+	}
+	if len(c.operations) != 0 {
+		lines = append(lines, fmt.Sprintf("breaks: %s", strings.Join(c.operations, ", ")))
+	}
+	if c.accepted != nil {
+		lines = append(lines, fmt.Sprintf("accepted: %s", acceptedText(c)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func containsCriticality(criticalities []Criticality, criticality Criticality) bool {
+	for _, c := range criticalities {
+		if c == criticality {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package compare
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	tests := []struct {
+		name     string
+		failOn   []Criticality
+		suites   []string
+		failures int
+	}{
+		{
+			name:     "Breaking changes fail by default",
+			suites:   []string{"Breaking", "Dangerous", "NonBreaking", "StaleAccepted"},
+			failures: 1,
+		},
+		{
+			name:     "Dangerous changes fail",
+			failOn:   []Criticality{Breaking, Dangerous},
+			suites:   []string{"Breaking", "Dangerous", "NonBreaking", "StaleAccepted"},
+			failures: 2,
+		},
+	}
+	stale := []*StaleAcceptedChange{{AcceptedChange: &AcceptedChange{ChangeType: TypeRemoved, Path: "Shelf"}, Reason: "no change matches it"}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			reporter := NewJUnitReporter(&buf)
+			reporter.FailOn = tt.failOn
+			if err := WriteReport(reporter, reporterChanges(t), stale); err != nil {
+				t.Fatalf("WriteReport() error = %v", err)
+			}
+			if !strings.HasPrefix(buf.String(), xml.Header) {
+				t.Errorf("report doesn't start with the xml header = %v", buf.String())
+			}
+			var report junitTestSuites
+			if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+				t.Fatalf("invalid junit report, error = %v", err)
+			}
+			if report.Tests != 5 || report.Failures != tt.failures {
+				t.Errorf("report tests = %d, failures = %d, want 5 tests and %d failures", report.Tests, report.Failures, tt.failures)
+			}
+			if len(report.Suites) != len(tt.suites) {
+				t.Fatalf("Unexpected test suites = %+v", report.Suites)
+			}
+			for i, name := range tt.suites {
+				if report.Suites[i].Name != name {
+					t.Errorf("test suite = %v, want %v", report.Suites[i].Name, name)
+				}
+			}
+			removed := report.Suites[0].Cases[0]
+			if removed.ClassName != string(FieldRemoved) || removed.Name != "Book.title" || removed.File != "old.graphql" || removed.Line != 3 || removed.Failure == nil {
+				t.Errorf("Unexpected test case = %+v", removed)
+			}
+			if skipped := report.Suites[3].Cases[0]; skipped.Skipped == nil || report.Suites[3].Skipped != 1 {
+				t.Errorf("stale accepted change isn't skipped = %+v", skipped)
+			}
+		})
+	}
+}
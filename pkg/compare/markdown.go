@@ -0,0 +1,142 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// MarkdownReporter writes the changes as a pull request comment: a summary of the number of changes of every criticality,
+// followed by a collapsible table of the changes of every criticality. The output only depends on the changes, so comments
+// of reruns are identical.
+type MarkdownReporter struct {
+	// LinkTemplate links the positions in the new schema to their source, {file} and {line} are replaced with the position
+	// e.g. https://github.com/org/repo/blob/main/{file}#L{line}, when it has no placeholders {file}#L{line} is appended to it
+	LinkTemplate string
+	// OldLinkTemplate links the positions in the old schema to their source, LinkTemplate is used when it is empty
+	OldLinkTemplate string
+
+	w       io.Writer
+	changes []*Change
+}
+
+// NewMarkdownReporter creates a Markdown reporter writing to w
+func NewMarkdownReporter(w io.Writer) *MarkdownReporter {
+	return &MarkdownReporter{w: w}
+}
+
+// Begin resets the changes of a previous report
+func (r *MarkdownReporter) Begin() error {
+	r.changes = nil
+	return nil
+}
+
+// Report collects the change, changes are written grouped by criticality at the end
+func (r *MarkdownReporter) Report(change *Change) error {
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+// End writes the summary, a collapsible table of the changes of every criticality and the list of stale accepted changes
+func (r *MarkdownReporter) End(stale []*StaleAcceptedChange) error {
+	groups := GroupChanges(r.changes)
+	blocks := []string{"## GraphQL schema changes", markdownSummary(groups)}
+	if len(r.changes) == 0 {
+		blocks = append(blocks, "No changes found on schema compare!")
+	}
+	for _, criticality := range criticalitiesBySeverity() {
+		changes := SortChanges(groups[criticality])
+		if len(changes) == 0 {
+			continue
+		}
+		rows := []string{"| Change type | Path | Message | Location |", "| --- | --- | --- | --- |"}
+		for _, c := range changes {
+			message := markdownEscape(c.message)
+			if len(c.operations) != 0 {
+				message = fmt.Sprintf("%s<br>breaks: %s", message, markdownEscape(strings.Join(c.operations, ", ")))
+			}
+			if c.accepted != nil {
+				message = fmt.Sprintf("%s<br>accepted: %s", message, markdownEscape(acceptedText(c)))
+			}
+			rows = append(rows, fmt.Sprintf("| `%s` | `%s` | %s | %s |", c.changeType, c.path, message, r.location(c)))
+		}
+		// breaking changes are expanded, the other criticalities are collapsed
+		blocks = append(blocks, markdownDetails(criticality == Breaking, fmt.Sprintf("%s %s (%d)", criticalityIcons[criticality], criticality, len(changes)), rows))
+	}
+	if len(stale) != 0 {
+		items := make([]string, 0, len(stale))
+		for _, s := range stale {
+			reason := markdownEscape(s.Reason)
+			if s.Criticality != nil {
+				reason = fmt.Sprintf("%s, the %s change is no longer accepted", reason, s.Criticality)
+			}
+			items = append(items, fmt.Sprintf("- `%s` `%s`: %s", s.ChangeType, s.Path, reason))
+		}
+		blocks = append(blocks, markdownDetails(false, fmt.Sprintf("⚠️ Stale accepted changes (%d)", len(stale)), items))
+	}
+	_, err := io.WriteString(r.w, strings.Join(blocks, "\n\n")+"\n")
+	return err
+}
+
+// markdownSummary is a table of the number of changes of every criticality
+func markdownSummary(groups map[Criticality][]*Change) string {
+	var header, separator, counts []string
+	for _, criticality := range criticalitiesBySeverity() {
+		header = append(header, fmt.Sprintf("%s %s", criticalityIcons[criticality], criticality))
+		separator = append(separator, ":---:")
+		counts = append(counts, strconv.Itoa(len(groups[criticality])))
+	}
+	return fmt.Sprintf("| %s |\n| %s |\n| %s |", strings.Join(header, " | "), strings.Join(separator, " | "), strings.Join(counts, " | "))
+}
+
+// markdownDetails is a collapsible section with the summary and lines
+func markdownDetails(open bool, summary string, lines []string) string {
+	tag := "<details>"
+	if open {
+		tag = "<details open>"
+	}
+	return fmt.Sprintf("%s\n<summary>%s</summary>\n\n%s\n\n</details>", tag, summary, strings.Join(lines, "\n"))
+}
+
+// location links the old and new position of the change, or the position it is reported at when they aren't known
+func (r *MarkdownReporter) location(c *Change) string {
+	if c.oldPosition == nil && c.newPosition == nil {
+		return r.positionLink(c.position, r.LinkTemplate)
+	}
+	var links []string
+	if c.oldPosition != nil {
+		oldTemplate := r.OldLinkTemplate
+		if len(oldTemplate) == 0 {
+			oldTemplate = r.LinkTemplate
+		}
+		links = append(links, r.positionLink(c.oldPosition, oldTemplate))
+	}
+	if c.newPosition != nil {
+		links = append(links, r.positionLink(c.newPosition, r.LinkTemplate))
+	}
+	return strings.Join(links, " → ")
+}
+
+// positionLink prints the position as file:line, linked to the source when there is a template and the file is known
+func (r *MarkdownReporter) positionLink(position *ast.Position, template string) string {
+	text := markdownEscape(formatPosition(position))
+	if len(template) == 0 || position == nil || position.Src == nil || len(position.Src.Name) == 0 {
+		return text
+	}
+	file := strings.TrimPrefix(filepath.ToSlash(position.Src.Name), "./")
+	line := strconv.Itoa(position.Line)
+	if !strings.Contains(template, "{file}") && !strings.Contains(template, "{line}") {
+		template = fmt.Sprintf("%s/{file}#L{line}", strings.TrimSuffix(template, "/"))
+	}
+	link := strings.NewReplacer("{file}", file, "{line}", line).Replace(template)
+	return fmt.Sprintf("[%s](%s)", text, link)
+}
+
+// markdownEscape escapes the characters which would break a table cell or be rendered as markup
+func markdownEscape(text string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ", "<", "&lt;", ">", "&gt;", "*", "\\*", "_", "\\_").Replace(strings.TrimSpace(text))
+}
@@ -0,0 +1,175 @@
+package compare
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const markdownSummaryHeader = "| ❌ Breaking | ✋️ Dangerous | 🗑️ SafeUnused | ☑️ Accepted | ✅ NonBreaking |\n" +
+	"| :---: | :---: | :---: | :---: | :---: |\n"
+
+func TestMarkdownReporter(t *testing.T) {
+	stale := []*StaleAcceptedChange{{AcceptedChange: &AcceptedChange{ChangeType: TypeRemoved, Path: "Shelf"}, Reason: "expired on 2024-01-01"}}
+	breaking := Breaking
+	tests := []struct {
+		name            string
+		linkTemplate    string
+		oldLinkTemplate string
+		changes         []*Change
+		stale           []*StaleAcceptedChange
+		want            string
+	}{
+		{
+			name:    "Changes grouped by criticality",
+			changes: reporterChanges(t),
+			want: "## GraphQL schema changes\n\n" +
+				markdownSummaryHeader +
+				"| 1 | 1 | 0 | 0 | 2 |\n\n" +
+				"<details open>\n<summary>❌ Breaking (1)</summary>\n\n" +
+				"| Change type | Path | Message | Location |\n| --- | --- | --- | --- |\n" +
+				"| `FIELD_REMOVED` | `Book.title` | Field 'Book.title' was removed from OBJECT | old.graphql:3 |\n\n" +
+				"</details>\n\n" +
+				"<details>\n<summary>✋️ Dangerous (1)</summary>\n\n" +
+				"| Change type | Path | Message | Location |\n| --- | --- | --- | --- |\n" +
+				"| `UNION_MEMBER_ADDED` | `Result` | Member 'Author' was added to Union type 'Result' | old.graphql:5 → new.graphql:6 |\n\n" +
+				"</details>\n\n" +
+				"<details>\n<summary>✅ NonBreaking (2)</summary>\n\n" +
+				"| Change type | Path | Message | Location |\n| --- | --- | --- | --- |\n" +
+				"| `FIELD_ADDED` | `Book.author` | Field 'Book.author' was added to OBJECT | new.graphql:3 |\n" +
+				"| `TYPE_ADDED` | `Author` | Type 'Author' was added | new.graphql:5 |\n\n" +
+				"</details>\n",
+		},
+		{
+			name:         "Positions linked with a base url",
+			linkTemplate: "https://github.com/org/repo/blob/head/",
+			changes: []*Change{{
+				changeType:       FieldAdded,
+				criticalityLevel: NonBreaking,
+				message:          "Field 'Book.author' was added to OBJECT",
+				path:             "Book.author",
+				position:         &ast.Position{Line: 3, Src: &ast.Source{Name: "./schema/new.graphql"}},
+				newPosition:      &ast.Position{Line: 3, Src: &ast.Source{Name: "./schema/new.graphql"}},
+			}},
+			want: "## GraphQL schema changes\n\n" +
+				markdownSummaryHeader +
+				"| 0 | 0 | 0 | 0 | 1 |\n\n" +
+				"<details>\n<summary>✅ NonBreaking (1)</summary>\n\n" +
+				"| Change type | Path | Message | Location |\n| --- | --- | --- | --- |\n" +
+				"| `FIELD_ADDED` | `Book.author` | Field 'Book.author' was added to OBJECT | [./schema/new.graphql:3](https://github.com/org/repo/blob/head/schema/new.graphql#L3) |\n\n" +
+				"</details>\n",
+		},
+		{
+			name:            "Old and new positions linked with templates",
+			linkTemplate:    "https://example.com/head/{file}?line={line}",
+			oldLinkTemplate: "https://example.com/base/{file}?line={line}",
+			changes: []*Change{{
+				changeType:       FieldTypeChanged,
+				criticalityLevel: Breaking,
+				message:          "Field 'Book.title' type changed from 'String!' to 'String' in OBJECT ",
+				path:             "Book.title",
+				position:         &ast.Position{Line: 4, Src: &ast.Source{Name: "new.graphql"}},
+				oldPosition:      &ast.Position{Line: 2, Src: &ast.Source{Name: "old.graphql"}},
+				newPosition:      &ast.Position{Line: 4, Src: &ast.Source{Name: "new.graphql"}},
+				operations:       []string{"Books (web/books.graphql)"},
+			}},
+			want: "## GraphQL schema changes\n\n" +
+				markdownSummaryHeader +
+				"| 1 | 0 | 0 | 0 | 0 |\n\n" +
+				"<details open>\n<summary>❌ Breaking (1)</summary>\n\n" +
+				"| Change type | Path | Message | Location |\n| --- | --- | --- | --- |\n" +
+				"| `FIELD_TYPE_CHANGED` | `Book.title` | Field 'Book.title' type changed from 'String!' to 'String' in OBJECT<br>breaks: Books (web/books.graphql) | " +
+				"[old.graphql:2](https://example.com/base/old.graphql?line=2) → [new.graphql:4](https://example.com/head/new.graphql?line=4) |\n\n" +
+				"</details>\n",
+		},
+		{
+			name: "Accepted change with the criticality it had",
+			changes: []*Change{{
+				changeType:       FieldRemoved,
+				criticalityLevel: Accepted,
+				message:          "Field 'Book.year' was removed from OBJECT",
+				path:             "Book.year",
+				position:         &ast.Position{Line: 2, Src: &ast.Source{Name: "old.graphql"}},
+				accepted:         &AcceptedChange{ChangeType: FieldRemoved, Path: "Book.year", Justification: "no client reads the year"},
+				acceptedFrom:     Breaking,
+			}},
+			stale: []*StaleAcceptedChange{{AcceptedChange: &AcceptedChange{ChangeType: FieldRemoved, Path: "Book.title"}, Reason: "expired on 2024-01-01", Criticality: &breaking}},
+			want: "## GraphQL schema changes\n\n" +
+				markdownSummaryHeader +
+				"| 0 | 0 | 0 | 1 | 0 |\n\n" +
+				"<details>\n<summary>☑️ Accepted (1)</summary>\n\n" +
+				"| Change type | Path | Message | Location |\n| --- | --- | --- | --- |\n" +
+				"| `FIELD_REMOVED` | `Book.year` | Field 'Book.year' was removed from OBJECT<br>accepted: Breaking, no client reads the year | old.graphql:2 |\n\n" +
+				"</details>\n\n" +
+				"<details>\n<summary>⚠️ Stale accepted changes (1)</summary>\n\n" +
+				"- `FIELD_REMOVED` `Book.title`: expired on 2024-01-01, the Breaking change is no longer accepted\n\n" +
+				"</details>\n",
+		},
+		{
+			name:  "No changes with stale accepted changes",
+			stale: stale,
+			want: "## GraphQL schema changes\n\n" +
+				markdownSummaryHeader +
+				"| 0 | 0 | 0 | 0 | 0 |\n\n" +
+				"No changes found on schema compare!\n\n" +
+				"<details>\n<summary>⚠️ Stale accepted changes (1)</summary>\n\n" +
+				"- `TYPE_REMOVED` `Shelf`: expired on 2024-01-01\n\n" +
+				"</details>\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			reporter := NewMarkdownReporter(&buf)
+			reporter.LinkTemplate = tt.linkTemplate
+			reporter.OldLinkTemplate = tt.oldLinkTemplate
+			if err := WriteReport(reporter, tt.changes, tt.stale); err != nil {
+				t.Fatalf("WriteReport() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("MarkdownReporter output =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownReporterIsDeterministic(t *testing.T) {
+	position := &ast.Position{Line: 1, Src: &ast.Source{Name: "new.graphql"}}
+	changes := []*Change{
+		{changeType: DirectiveAdded, criticalityLevel: NonBreaking, message: "Directive '@tag' was added in 'Book'", path: "Book", position: position},
+		{changeType: FieldAdded, criticalityLevel: NonBreaking, message: "Field 'Book.title' was added to OBJECT", path: "Book.title", position: position},
+		{changeType: DirectiveAdded, criticalityLevel: NonBreaking, message: "Directive '@key' was added in 'Book'", path: "Book", position: position},
+	}
+	reversed := []*Change{changes[2], changes[1], changes[0]}
+	var first, second bytes.Buffer
+	if err := WriteReport(NewMarkdownReporter(&first), changes, nil); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	if err := WriteReport(NewMarkdownReporter(&second), reversed, nil); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	if first.String() != second.String() {
+		t.Errorf("MarkdownReporter output depends on the order of the changes =\n%s\nand\n%s", first.String(), second.String())
+	}
+}
+
+func TestMarkdownEscape(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "Member 'A' was added to Union type 'A | B'", want: "Member 'A' was added to Union type 'A \\| B'"},
+		{text: "Argument 'first' default value changed from '<nil>' to '10' ", want: "Argument 'first' default value changed from '&lt;nil&gt;' to '10'"},
+		{text: "Directive '@auth' was removed from 'Query.my_books'", want: "Directive '@auth' was removed from 'Query.my\\_books'"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.text, func(t *testing.T) {
+			if got := markdownEscape(tt.text); got != tt.want {
+				t.Errorf("markdownEscape() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Operations are the client operations which breaking changes are checked against
+type Operations struct {
+	operations []*clientOperation
+	fragments  map[string]*ast.FragmentDefinition
+}
+
+type clientOperation struct {
+	name       string
+	definition *ast.OperationDefinition
+}
+
+// persistedQueryManifest is the apollo persisted query manifest format
+type persistedQueryManifest struct {
+	Format     string `json:"format"`
+	Operations []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// ParseOperations parses the client operations from GraphQL documents, files with the .json extension are read as
+// persisted query manifests, either in the apollo format or as a map of the query ids to the queries.
+// Fragments can be defined in any of the files.
+func ParseOperations(operationFileContents map[string][]byte) (*Operations, error) {
+	fileNames := make([]string, 0, len(operationFileContents))
+	for fileName := range operationFileContents {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	operations := &Operations{fragments: map[string]*ast.FragmentDefinition{}}
+	for _, fileName := range fileNames {
+		content := operationFileContents[fileName]
+		if !strings.EqualFold(filepath.Ext(fileName), ".json") {
+			if err := operations.add(fileName, fileName, string(content), ""); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		queries, err := parsePersistedQueries(fileName, content)
+		if err != nil {
+			return nil, err
+		}
+		for _, query := range queries {
+			if err := operations.add(fmt.Sprintf("%s#%s", fileName, query.id), fileName, query.body, query.name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return operations, nil
+}
+
+type persistedQuery struct {
+	id   string
+	name string
+	body string
+}
+
+// parsePersistedQueries reads the queries of a persisted query manifest
+func parsePersistedQueries(fileName string, content []byte) ([]persistedQuery, error) {
+	var queries []persistedQuery
+	var manifest persistedQueryManifest
+	if err := json.Unmarshal(content, &manifest); err == nil && (len(manifest.Format) != 0 || manifest.Operations != nil) {
+		for _, operation := range manifest.Operations {
+			queries = append(queries, persistedQuery{id: operation.ID, name: operation.Name, body: operation.Body})
+		}
+		return queries, nil
+	}
+	var queryMap map[string]string
+	if err := json.Unmarshal(content, &queryMap); err != nil {
+		return nil, fmt.Errorf("invalid persisted query manifest:%s, error:%v", fileName, err)
+	}
+	for id, body := range queryMap {
+		queries = append(queries, persistedQuery{id: id, body: body})
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].id < queries[j].id
+	})
+	return queries, nil
+}
+
+// add parses the document and adds its operations and fragments
+func (o *Operations) add(sourceName string, fileName string, document string, name string) error {
+	doc, err := parser.ParseQuery(&ast.Source{Name: fileName, Input: document})
+	if err != nil {
+		return fmt.Errorf("failed to parse operations:%s, error:%v", sourceName, err)
+	}
+	for _, fragment := range doc.Fragments {
+		o.fragments[fragment.Name] = fragment
+	}
+	for _, operation := range doc.Operations {
+		operationName, operationSource := operation.Name, sourceName
+		if len(operationName) == 0 {
+			operationName = name
+		}
+		if len(operationName) == 0 {
+			operationName = "anonymous"
+			if operation.Position != nil && sourceName == fileName {
+				operationSource = fmt.Sprintf("%s:%d", fileName, operation.Position.Line)
+			}
+		}
+		o.operations = append(o.operations, &clientOperation{
+			name:       fmt.Sprintf("%s (%s)", operationName, operationSource),
+			definition: operation,
+		})
+	}
+	return nil
+}
+
+// Len returns the number of client operations
+func (o *Operations) Len() int {
+	return len(o.operations)
+}
+
+// operationUsage resolves the schema coordinates touched by the operations against the schema, and maps every
+// coordinate to the names of the operations using it
+func (o *Operations) operationUsage(schema *ast.SchemaDocument) map[string][]string {
+	usage := map[string][]string{}
+	index := newSchemaIndex(schema)
+	for _, operation := range o.operations {
+		walker := &usageWalker{
+			schema:           index,
+			fragments:        o.fragments,
+			coordinates:      map[string]bool{},
+			visitedFragments: map[string]bool{},
+			visitedTypes:     map[string]bool{},
+		}
+		walker.operation(operation.definition)
+		for coordinate := range walker.coordinates {
+			usage[coordinate] = append(usage[coordinate], operation.name)
+		}
+	}
+	for coordinate := range usage {
+		sort.Strings(usage[coordinate])
+	}
+	return usage
+}
+
+// schemaIndex finds the definitions of the schema by name, with their extensions merged
+type schemaIndex struct {
+	types      map[string]*ast.Definition
+	directives map[string]*ast.DirectiveDefinition
+	rootTypes  map[ast.Operation]string
+}
+
+func newSchemaIndex(schema *ast.SchemaDocument) *schemaIndex {
+	index := &schemaIndex{
+		types:      map[string]*ast.Definition{},
+		directives: map[string]*ast.DirectiveDefinition{},
+		rootTypes: map[ast.Operation]string{
+			ast.Query:        "Query",
+			ast.Mutation:     "Mutation",
+			ast.Subscription: "Subscription",
+		},
+	}
+	for _, definitions := range []ast.DefinitionList{schema.Definitions, schema.Extensions} {
+		for _, definition := range definitions {
+			merged, ok := index.types[definition.Name]
+			if !ok {
+				copied := *definition
+				index.types[definition.Name] = &copied
+				continue
+			}
+			merged.Directives = append(append(ast.DirectiveList{}, merged.Directives...), definition.Directives...)
+			merged.Fields = append(append(ast.FieldList{}, merged.Fields...), definition.Fields...)
+			merged.EnumValues = append(append(ast.EnumValueList{}, merged.EnumValues...), definition.EnumValues...)
+		}
+	}
+	for _, directive := range schema.Directives {
+		index.directives[directive.Name] = directive
+	}
+	for _, schemaDefinitions := range []ast.SchemaDefinitionList{schema.Schema, schema.SchemaExtension} {
+		for _, schemaDefinition := range schemaDefinitions {
+			for _, operationType := range schemaDefinition.OperationTypes {
+				index.rootTypes[operationType.Operation] = operationType.Type
+			}
+		}
+	}
+	return index
+}
+
+// usageWalker collects the schema coordinates touched by one operation
+type usageWalker struct {
+	schema           *schemaIndex
+	fragments        map[string]*ast.FragmentDefinition
+	coordinates      map[string]bool
+	visitedFragments map[string]bool
+	visitedTypes     map[string]bool
+}
+
+func (w *usageWalker) operation(operation *ast.OperationDefinition) {
+	for _, variable := range operation.VariableDefinitions {
+		// the variable values aren't known, so every member of the input types can be used
+		w.inputType(variable.Type.Name())
+		w.value(variable.Type, variable.DefaultValue)
+		w.directives(variable.Directives)
+	}
+	w.directives(operation.Directives)
+	w.selectionSet(w.schema.rootTypes[operation.Operation], operation.SelectionSet)
+}
+
+func (w *usageWalker) selectionSet(typeName string, selectionSet ast.SelectionSet) {
+	w.coordinates[typeName] = true
+	for _, selection := range selectionSet {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			w.field(typeName, selection)
+		case *ast.InlineFragment:
+			w.directives(selection.Directives)
+			fragmentType := typeName
+			if len(selection.TypeCondition) != 0 {
+				fragmentType = selection.TypeCondition
+			}
+			w.selectionSet(fragmentType, selection.SelectionSet)
+		case *ast.FragmentSpread:
+			w.directives(selection.Directives)
+			fragment, ok := w.fragments[selection.Name]
+			if !ok || w.visitedFragments[selection.Name] {
+				continue
+			}
+			w.visitedFragments[selection.Name] = true
+			w.directives(fragment.Directives)
+			w.selectionSet(fragment.TypeCondition, fragment.SelectionSet)
+		}
+	}
+}
+
+func (w *usageWalker) field(typeName string, field *ast.Field) {
+	w.directives(field.Directives)
+	if strings.HasPrefix(field.Name, "__") {
+		return
+	}
+	coordinate := fmt.Sprintf("%s.%s", typeName, field.Name)
+	w.coordinates[coordinate] = true
+	var fieldDefinition *ast.FieldDefinition
+	if definition, ok := w.schema.types[typeName]; ok {
+		fieldDefinition = definition.Fields.ForName(field.Name)
+	}
+	for _, argument := range field.Arguments {
+		w.coordinates[fmt.Sprintf("%s.%s", coordinate, argument.Name)] = true
+		if fieldDefinition == nil {
+			continue
+		}
+		if argumentDefinition := fieldDefinition.Arguments.ForName(argument.Name); argumentDefinition != nil {
+			w.value(argumentDefinition.Type, argument.Value)
+		}
+	}
+	if fieldDefinition == nil {
+		return
+	}
+	returnType := fieldDefinition.Type.Name()
+	if definition, ok := w.schema.types[returnType]; ok && definition.Kind == ast.Enum {
+		// any value of the enum can be returned to the client
+		w.inputType(returnType)
+	}
+	w.selectionSet(returnType, field.SelectionSet)
+}
+
+func (w *usageWalker) directives(directives ast.DirectiveList) {
+	for _, directive := range directives {
+		coordinate := fmt.Sprintf("@%s", directive.Name)
+		w.coordinates[coordinate] = true
+		definition := w.schema.directives[directive.Name]
+		for _, argument := range directive.Arguments {
+			w.coordinates[fmt.Sprintf("%s.%s", coordinate, argument.Name)] = true
+			if definition == nil {
+				continue
+			}
+			if argumentDefinition := definition.Arguments.ForName(argument.Name); argumentDefinition != nil {
+				w.value(argumentDefinition.Type, argument.Value)
+			}
+		}
+	}
+}
+
+// value collects the input types, input fields and enum values used by a literal value of the type
+func (w *usageWalker) value(typ *ast.Type, value *ast.Value) {
+	if typ == nil || value == nil {
+		return
+	}
+	w.coordinates[typ.Name()] = true
+	switch value.Kind {
+	case ast.ListValue:
+		elemType := typ
+		if typ.Elem != nil {
+			elemType = typ.Elem
+		}
+		for _, child := range value.Children {
+			w.value(elemType, child.Value)
+		}
+	case ast.ObjectValue:
+		definition, ok := w.schema.types[typ.Name()]
+		for _, child := range value.Children {
+			w.coordinates[fmt.Sprintf("%s.%s", typ.Name(), child.Name)] = true
+			if !ok {
+				continue
+			}
+			if fieldDefinition := definition.Fields.ForName(child.Name); fieldDefinition != nil {
+				w.value(fieldDefinition.Type, child.Value)
+			}
+		}
+	case ast.EnumValue:
+		w.coordinates[fmt.Sprintf("%s.%s", typ.Name(), value.Raw)] = true
+	}
+}
+
+// inputType collects the input type with all its fields and enum values, recursively
+func (w *usageWalker) inputType(typeName string) {
+	if w.visitedTypes[typeName] {
+		return
+	}
+	w.visitedTypes[typeName] = true
+	w.coordinates[typeName] = true
+	definition, ok := w.schema.types[typeName]
+	if !ok {
+		return
+	}
+	for _, enumValue := range definition.EnumValues {
+		w.coordinates[fmt.Sprintf("%s.%s", typeName, enumValue.Name)] = true
+	}
+	if definition.Kind != ast.InputObject {
+		return
+	}
+	for _, field := range definition.Fields {
+		w.coordinates[fmt.Sprintf("%s.%s", typeName, field.Name)] = true
+		w.inputType(field.Type.Name())
+	}
+}
+
+// applyOperationUsage downgrades the breaking changes which none of the operations use to SafeUnused,
+// and lists the operations the other breaking changes break
+func applyOperationUsage(changes []*Change, oldSchema *ast.SchemaDocument, operations *Operations) {
+	usage := operations.operationUsage(oldSchema)
+	for _, change := range changes {
+		if change.criticalityLevel != Breaking {
+			continue
+		}
+		coordinate := usageCoordinate(change)
+		if len(coordinate) == 0 {
+			// e.g. changes of the schema root operations can't be matched with operations
+			continue
+		}
+		if operationNames, ok := usage[coordinate]; ok {
+			change.operations = operationNames
+			continue
+		}
+		change.criticalityLevel = SafeUnused
+	}
+}
+
+// usageCoordinate returns the schema coordinate operations have to use to be broken by the change
+func usageCoordinate(change *Change) string {
+	switch change.changeType {
+	case FieldArgumentAdded, InputFieldAdded, DirectiveArgumentAdded:
+		// required arguments and input fields break the operations using their field, input type or directive
+		if i := strings.LastIndex(change.path, "."); i > 0 {
+			return change.path[:i]
+		}
+	}
+	return change.path
+}
@@ -0,0 +1,319 @@
+package compare
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const operationsOldSchema = `
+directive @cached(ttl: Int) on FIELD
+
+type Query {
+	books(filter: BookFilter, sort: Sort = TITLE): [Book]
+	book(isbn: ID!): Book
+	shelves: [Shelf]
+	search(term: String): [SearchResult]
+}
+
+type Mutation {
+	addBook(input: BookInput!): Book
+}
+
+interface Node {
+	id: ID!
+}
+
+type Book implements Node {
+	id: ID!
+	isbn: ID!
+	title: String
+	year: Int
+	status: Status
+}
+
+type Author {
+	name: String
+	born: Int
+}
+
+type Shelf {
+	id: ID!
+	label: String
+}
+
+union SearchResult = Book | Author
+
+enum Status {
+	AVAILABLE
+	LENT
+}
+
+enum Sort {
+	TITLE
+	YEAR
+}
+
+input BookFilter {
+	title: String
+	year: Int
+	status: Status
+}
+
+input BookInput {
+	isbn: ID!
+	title: String
+}
+`
+
+func TestFindChangesInSchemasWithOperations(t *testing.T) {
+	tests := []struct {
+		name           string
+		newSchema      string
+		removedTypes   []string
+		operations     map[string]string
+		path           string
+		changeType     ChangeType
+		criticality    Criticality
+		wantOperations []string
+	}{
+		{
+			name:       "removed field used by an operation",
+			newSchema:  `type Book { id: ID! isbn: ID! year: Int status: Status }`,
+			operations: map[string]string{"clients/book.graphql": `query GetBook { book(isbn: "1") { title } }`},
+			path:       "Book.title", changeType: FieldRemoved, criticality: Breaking,
+			wantOperations: []string{"GetBook (clients/book.graphql)"},
+		},
+		{
+			name:       "removed field no operation uses",
+			newSchema:  `type Book { id: ID! isbn: ID! title: String status: Status }`,
+			operations: map[string]string{"clients/book.graphql": `query GetBook { book(isbn: "1") { title } }`},
+			path:       "Book.year", changeType: FieldRemoved, criticality: SafeUnused,
+		},
+		{
+			name:      "removed field used through a fragment defined in another file",
+			newSchema: `type Book { id: ID! isbn: ID! title: String status: Status }`,
+			operations: map[string]string{
+				"clients/fragments.graphql": `fragment BookFields on Book { year }`,
+				"clients/book.graphql":      `query GetBook { book(isbn: "1") { ...BookFields } }`,
+			},
+			path: "Book.year", changeType: FieldRemoved, criticality: Breaking,
+			wantOperations: []string{"GetBook (clients/book.graphql)"},
+		},
+		{
+			name:       "removed field used through an inline fragment on a union member",
+			newSchema:  `type Author { name: String }`,
+			operations: map[string]string{"clients/search.graphql": `query Search { search(term: "go") { ... on Author { born } } }`},
+			path:       "Author.born", changeType: FieldRemoved, criticality: Breaking,
+			wantOperations: []string{"Search (clients/search.graphql)"},
+		},
+		{
+			name:       "removed argument used by an operation",
+			newSchema:  `type Query { books(filter: BookFilter): [Book] book(isbn: ID!): Book shelves: [Shelf] search(term: String): [SearchResult] }`,
+			operations: map[string]string{"clients/books.graphql": `query Books { books(sort: YEAR) { title } }`},
+			path:       "Query.books.sort", changeType: FieldArgumentRemoved, criticality: Breaking,
+			wantOperations: []string{"Books (clients/books.graphql)"},
+		},
+		{
+			name:       "required argument added to a field used by an operation",
+			newSchema:  `type Query { books(filter: BookFilter, sort: Sort = TITLE): [Book] book(isbn: ID!): Book shelves(limit: Int!): [Shelf] search(term: String): [SearchResult] }`,
+			operations: map[string]string{"clients/shelves.graphql": `{ shelves { label } }`},
+			path:       "Query.shelves.limit", changeType: FieldArgumentAdded, criticality: Breaking,
+			wantOperations: []string{"anonymous (clients/shelves.graphql:1)"},
+		},
+		{
+			name:       "required argument added to a field no operation uses",
+			newSchema:  `type Query { books(filter: BookFilter, sort: Sort = TITLE): [Book] book(isbn: ID!): Book shelves(limit: Int!): [Shelf] search(term: String): [SearchResult] }`,
+			operations: map[string]string{"clients/books.graphql": `query Books { books { title } }`},
+			path:       "Query.shelves.limit", changeType: FieldArgumentAdded, criticality: SafeUnused,
+		},
+		{
+			name:       "removed enum value used in a literal argument",
+			newSchema:  `enum Sort { TITLE }`,
+			operations: map[string]string{"clients/books.graphql": `query Books { books(sort: YEAR) { title } }`},
+			path:       "Sort.YEAR", changeType: EnumValueRemoved, criticality: Breaking,
+			wantOperations: []string{"Books (clients/books.graphql)"},
+		},
+		{
+			name:       "removed enum value of a field selected by an operation",
+			newSchema:  `enum Status { AVAILABLE }`,
+			operations: map[string]string{"clients/book.graphql": `query GetBook { book(isbn: "1") { status } }`},
+			path:       "Status.LENT", changeType: EnumValueRemoved, criticality: Breaking,
+			wantOperations: []string{"GetBook (clients/book.graphql)"},
+		},
+		{
+			name:       "removed input field passed with a variable",
+			newSchema:  `input BookFilter { title: String }`,
+			operations: map[string]string{"clients/books.graphql": `query Books($filter: BookFilter) { books(filter: $filter) { title } }`},
+			path:       "BookFilter.year", changeType: InputFieldRemoved, criticality: Breaking,
+			wantOperations: []string{"Books (clients/books.graphql)"},
+		},
+		{
+			name:       "removed input field not passed in a literal",
+			newSchema:  `input BookFilter { title: String }`,
+			operations: map[string]string{"clients/books.graphql": `query Books { books(filter: {title: "go"}) { title } }`},
+			path:       "BookFilter.year", changeType: InputFieldRemoved, criticality: SafeUnused,
+		},
+		{
+			name:         "removed type used by a mutation",
+			newSchema:    `type Mutation { addBook(isbn: ID!): Book }`,
+			removedTypes: []string{"BookInput"},
+			operations:   map[string]string{"clients/add.graphql": `mutation AddBook($input: BookInput!) { addBook(input: $input) { id } }`},
+			path:         "BookInput", changeType: TypeRemoved, criticality: Breaking,
+			wantOperations: []string{"AddBook (clients/add.graphql)"},
+		},
+		{
+			name:       "removed directive argument used by an operation",
+			newSchema:  `directive @cached on FIELD`,
+			operations: map[string]string{"clients/shelves.graphql": `query Shelves { shelves @cached(ttl: 60) { id } }`},
+			path:       "@cached.ttl", changeType: DirectiveArgumentRemoved, criticality: Breaking,
+			wantOperations: []string{"Shelves (clients/shelves.graphql)"},
+		},
+		{
+			name:      "persisted query manifest in the apollo format",
+			newSchema: `type Shelf { id: ID! }`,
+			operations: map[string]string{"manifest.json": `{
+				"format": "apollo-persisted-query-manifest",
+				"version": 1,
+				"operations": [{"id": "abc", "name": "ShelfLabels", "type": "query", "body": "query ShelfLabels { shelves { label } }"}]
+			}`},
+			path: "Shelf.label", changeType: FieldRemoved, criticality: Breaking,
+			wantOperations: []string{"ShelfLabels (manifest.json#abc)"},
+		},
+		{
+			name:       "persisted query manifest mapping ids to queries",
+			newSchema:  `type Shelf { id: ID! }`,
+			operations: map[string]string{"queries.json": `{"def": "{ shelves { label } }", "abc": "query Books { books { title } }"}`},
+			path:       "Shelf.label", changeType: FieldRemoved, criticality: Breaking,
+			wantOperations: []string{"anonymous (queries.json#def)"},
+		},
+		{
+			name:      "operations breaking the same change are all listed",
+			newSchema: `type Shelf { id: ID! }`,
+			operations: map[string]string{
+				"clients/web/shelves.graphql":    `query WebShelves { shelves { label } }`,
+				"clients/mobile/shelves.graphql": `query MobileShelves { shelves { id label } }`,
+			},
+			path: "Shelf.label", changeType: FieldRemoved, criticality: Breaking,
+			wantOperations: []string{"MobileShelves (clients/mobile/shelves.graphql)", "WebShelves (clients/web/shelves.graphql)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{Name: "old.graphql", Input: operationsOldSchema})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{Name: "new.graphql", Input: replaceDefinitions(t, operationsOldSchema, tt.newSchema, tt.removedTypes...)})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			operationFileContents := map[string][]byte{}
+			for fileName, content := range tt.operations {
+				operationFileContents[fileName] = []byte(content)
+			}
+			operations, err := ParseOperations(operationFileContents)
+			if err != nil {
+				t.Fatalf("ParseOperations() error = %v", err)
+			}
+
+			var found *Change
+			for _, change := range FindChangesInSchemas(oldSchema, newSchema, WithOperations(operations)) {
+				if change.GetPath() == tt.path && change.GetChangeType() == tt.changeType {
+					found = change
+				}
+			}
+			if found == nil {
+				t.Fatalf("FindChangesInSchemas() change %s at %s not found", tt.changeType, tt.path)
+			}
+			if found.GetChangeCriticalityLevel() != tt.criticality {
+				t.Errorf("FindChangesInSchemas() criticality = %s, want %s", found.GetChangeCriticalityLevel(), tt.criticality)
+			}
+			if !reflect.DeepEqual(found.GetOperations(), tt.wantOperations) {
+				t.Errorf("FindChangesInSchemas() operations = %v, want %v", found.GetOperations(), tt.wantOperations)
+			}
+		})
+	}
+}
+
+func TestFindChangesInSchemasWithoutOperations(t *testing.T) {
+	oldSchema, err := parser.ParseSchema(&ast.Source{Name: "old.graphql", Input: operationsOldSchema})
+	if err != nil {
+		t.Fatalf("error parsing old schema, error = %v", err)
+	}
+	newSchema, err := parser.ParseSchema(&ast.Source{Name: "new.graphql", Input: replaceDefinitions(t, operationsOldSchema, `type Shelf { id: ID! }`)})
+	if err != nil {
+		t.Fatalf("error parsing new schema, error = %v", err)
+	}
+	for _, change := range FindChangesInSchemas(oldSchema, newSchema) {
+		if change.GetChangeCriticalityLevel() == SafeUnused || len(change.GetOperations()) != 0 {
+			t.Errorf("FindChangesInSchemas() change %s is checked against operations without WithOperations", change.GetPath())
+		}
+	}
+}
+
+func TestParseOperationsErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations map[string]string
+	}{
+		{
+			name:       "invalid operation",
+			operations: map[string]string{"clients/book.graphql": `query GetBook { book(isbn: "1") { title }`},
+		},
+		{
+			name:       "invalid manifest",
+			operations: map[string]string{"manifest.json": `["query { books { title } }"]`},
+		},
+		{
+			name:       "invalid operation in manifest",
+			operations: map[string]string{"manifest.json": `{"abc": "query {"}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operationFileContents := map[string][]byte{}
+			for fileName, content := range tt.operations {
+				operationFileContents[fileName] = []byte(content)
+			}
+			if _, err := ParseOperations(operationFileContents); err == nil {
+				t.Errorf("ParseOperations() expected error")
+			}
+		})
+	}
+}
+
+// replaceDefinitions replaces the definitions of the schema with the ones with the same name in replacements, and removes the removed types
+func replaceDefinitions(t *testing.T, schema string, replacements string, removedTypes ...string) string {
+	t.Helper()
+	doc, err := parser.ParseSchema(&ast.Source{Input: schema})
+	if err != nil {
+		t.Fatalf("error parsing schema, error = %v", err)
+	}
+	replacementDoc, err := parser.ParseSchema(&ast.Source{Input: replacements})
+	if err != nil {
+		t.Fatalf("error parsing replacements, error = %v", err)
+	}
+	definitions := ast.DefinitionList{}
+	for _, definition := range doc.Definitions {
+		if replacement := replacementDoc.Definitions.ForName(definition.Name); replacement != nil {
+			definition = replacement
+		}
+		if !containsString(removedTypes, definition.Name) {
+			definitions = append(definitions, definition)
+		}
+	}
+	doc.Definitions = definitions
+	for i, directive := range doc.Directives {
+		if replacement := replacementDoc.Directives.ForName(directive.Name); replacement != nil {
+			doc.Directives[i] = replacement
+		}
+	}
+	var b strings.Builder
+	formatter.NewFormatter(&b).FormatSchemaDocument(doc)
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// snippetContextLines is the number of source lines printed before and after the changed line in the snippets
+const snippetContextLines = 1
+
+// formatPosition prints the position as file:line, empty when the position is unknown
+func formatPosition(position *ast.Position) string {
+	if position == nil {
+		return ""
+	}
+	fileName := ""
+	if position.Src != nil {
+		fileName = position.Src.Name
+	}
+	return fmt.Sprintf("%s:%d", fileName, position.Line)
+}
+
+// getPositions prints the old and new position of the changed element e.g. old.graphql:4 → new.graphql:6,
+// only the side it exists on when it was added or removed
+func getPositions(c *Change) string {
+	if c.oldPosition == nil && c.newPosition == nil {
+		return getPosition(c)
+	}
+	var positions []string
+	for _, position := range []*ast.Position{c.oldPosition, c.newPosition} {
+		if position != nil {
+			positions = append(positions, formatPosition(position))
+		}
+	}
+	return strings.Join(positions, " → ")
+}
+
+// sourceSnippet returns the source lines around the position with the changed line marked, empty when the source isn't known
+// e.g. of schemas read from an endpoint
+func sourceSnippet(position *ast.Position, contextLines int) string {
+	if position == nil || position.Src == nil || len(position.Src.Input) == 0 {
+		return ""
+	}
+	lines := strings.Split(position.Src.Input, "\n")
+	if position.Line < 1 || position.Line > len(lines) {
+		return ""
+	}
+	first := position.Line - contextLines
+	if first < 1 {
+		first = 1
+	}
+	last := position.Line + contextLines
+	if last > len(lines) {
+		last = len(lines)
+	}
+	width := len(fmt.Sprint(last))
+	var snippet strings.Builder
+	for line := first; line <= last; line++ {
+		marker := " "
+		if line == position.Line {
+			marker = ">"
+		}
+		fmt.Fprintf(&snippet, "%s\n", strings.TrimRight(fmt.Sprintf("%s %*d | %s", marker, width, line, lines[line-1]), " \t\r"))
+	}
+	return snippet.String()
+}
+
+// writeSnippets writes the source snippets of the old and new position of the change
+func writeSnippets(w io.Writer, c *Change) {
+	for _, position := range []*ast.Position{c.oldPosition, c.newPosition} {
+		snippet := sourceSnippet(position, snippetContextLines)
+		if len(snippet) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "    %s\n", formatPosition(position))
+		for _, line := range strings.Split(strings.TrimSuffix(snippet, "\n"), "\n") {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
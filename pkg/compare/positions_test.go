@@ -0,0 +1,152 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestChangePositions(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldSchema  string
+		newSchema  string
+		changeType ChangeType
+		oldLine    int
+		newLine    int
+		positions  string
+	}{
+		{
+			name: "Removed input field points at the field in the old schema",
+			oldSchema: `input Filter {
+				title: String
+				year: Int
+			}`,
+			newSchema:  `input Filter { title: String }`,
+			changeType: InputFieldRemoved,
+			oldLine:    3,
+			positions:  "old.graphql:3",
+		},
+		{
+			name:      "Removed argument points at the argument in the old schema",
+			oldSchema: `type Query { books(title: String): [String] }`,
+			newSchema: `type Query {
+				books: [String]
+			}`,
+			changeType: FieldArgumentRemoved,
+			oldLine:    1,
+			positions:  "old.graphql:1",
+		},
+		{
+			name:      "Added field points at the field in the new schema",
+			oldSchema: `type Book { title: String }`,
+			newSchema: `type Book {
+				title: String
+				year: Int
+			}`,
+			changeType: FieldAdded,
+			newLine:    3,
+			positions:  "new.graphql:3",
+		},
+		{
+			name: "Changed field type points at the field in both schemas",
+			oldSchema: `type Book {
+				year: Int
+			}`,
+			newSchema: `type Book {
+
+				year: String
+			}`,
+			changeType: FieldTypeChanged,
+			oldLine:    2,
+			newLine:    3,
+			positions:  "old.graphql:2 → new.graphql:3",
+		},
+		{
+			name: "Removed directive points at the directive in the old schema",
+			oldSchema: `type Book
+				@cacheControl(maxAge: 60) { title: String }`,
+			newSchema:  `type Book { title: String }`,
+			changeType: DirectiveRemoved,
+			oldLine:    2,
+			positions:  "old.graphql:2",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Name:  "old.graphql",
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Name:  "new.graphql",
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema)
+			if len(changes) != 1 || changes[0].changeType != tt.changeType {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			if line := positionLine(changes[0].GetOldPosition()); line != tt.oldLine {
+				t.Errorf("old position line = %d, want %d", line, tt.oldLine)
+			}
+			if line := positionLine(changes[0].GetNewPosition()); line != tt.newLine {
+				t.Errorf("new position line = %d, want %d", line, tt.newLine)
+			}
+			if positions := getPositions(changes[0]); positions != tt.positions {
+				t.Errorf("getPositions() = %v, want %v", positions, tt.positions)
+			}
+		})
+	}
+}
+
+// positionLine is the line of the position, 0 when there is no position
+func positionLine(position *ast.Position) int {
+	if position == nil {
+		return 0
+	}
+	return position.Line
+}
+
+func TestSourceSnippet(t *testing.T) {
+	source := &ast.Source{Name: "schema.graphql", Input: "type Book {\n  title: String\n\n  year: Int\n}"}
+	tests := []struct {
+		name     string
+		position *ast.Position
+		want     string
+	}{
+		{
+			name:     "Line in the middle",
+			position: &ast.Position{Line: 4, Src: source},
+			want:     "  3 |\n> 4 |   year: Int\n  5 | }\n",
+		},
+		{
+			name:     "First line",
+			position: &ast.Position{Line: 1, Src: source},
+			want:     "> 1 | type Book {\n  2 |   title: String\n",
+		},
+		{
+			name:     "Source without input",
+			position: &ast.Position{Line: 1, Src: &ast.Source{Name: "endpoint"}},
+		},
+		{
+			name: "No position",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sourceSnippet(tt.position, snippetContextLines); got != tt.want {
+				t.Errorf("sourceSnippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
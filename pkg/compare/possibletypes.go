@@ -0,0 +1,138 @@
+package compare
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// possibleTypes returns the object types of every interface and union, interfaces implemented by other interfaces
+// are followed, so an object type implementing an interface is a possible type of all the interfaces it inherits
+func possibleTypes(index *schemaIndex) map[string]map[string]bool {
+	possible := map[string]map[string]bool{}
+	for _, definition := range index.types {
+		if definition.Kind == ast.Interface || definition.Kind == ast.Union {
+			possible[definition.Name] = map[string]bool{}
+		}
+	}
+	for _, definition := range index.types {
+		switch definition.Kind {
+		case ast.Object:
+			for name := range inheritedInterfaces(index, definition, map[string]bool{}) {
+				if _, ok := possible[name]; ok {
+					possible[name][definition.Name] = true
+				}
+			}
+		case ast.Union:
+			for _, member := range definition.Types {
+				if memberDefinition, ok := index.types[member]; ok && memberDefinition.Kind == ast.Object {
+					possible[definition.Name][member] = true
+				}
+			}
+		}
+	}
+	return possible
+}
+
+// inheritedInterfaces returns the interfaces the definition implements, directly or through other interfaces
+func inheritedInterfaces(index *schemaIndex, definition *ast.Definition, visited map[string]bool) map[string]bool {
+	for _, name := range definition.Interfaces {
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
+		if inherited, ok := index.types[name]; ok {
+			inheritedInterfaces(index, inherited, visited)
+		}
+	}
+	return visited
+}
+
+// isDirectPossibleType tells whether the object type is a possible type of the abstract type without inheritance,
+// as a union member or by implementing the interface itself
+func isDirectPossibleType(index *schemaIndex, abstractType string, objectType string) bool {
+	abstractDefinition, ok := index.types[abstractType]
+	if !ok {
+		return false
+	}
+	if abstractDefinition.Kind == ast.Union {
+		return containsString(abstractDefinition.Types, objectType)
+	}
+	objectDefinition, ok := index.types[objectType]
+	return ok && containsString(objectDefinition.Interfaces, abstractType)
+}
+
+// changeInPossibleTypes changes in the possible types of the interfaces and unions which aren't reported by the changes of
+// the union members and the implemented interfaces, i.e. the ones coming from interfaces implementing interfaces
+func changeInPossibleTypes(oldSchema *ast.SchemaDocument, newSchema *ast.SchemaDocument) []*Change {
+	var changes []*Change
+	oldIndex := newSchemaIndex(oldSchema)
+	newIndex := newSchemaIndex(newSchema)
+	oldPossible := possibleTypes(oldIndex)
+	newPossible := possibleTypes(newIndex)
+	abstractTypes := make([]string, 0, len(oldPossible))
+	for name := range oldPossible {
+		if _, ok := newPossible[name]; ok {
+			abstractTypes = append(abstractTypes, name)
+		}
+	}
+	sort.Strings(abstractTypes)
+	for _, abstractType := range abstractTypes {
+		definition := newIndex.types[abstractType]
+		for _, objectType := range sortedKeys(oldPossible[abstractType]) {
+			if newPossible[abstractType][objectType] || !isObjectType(newIndex, objectType) {
+				continue
+			}
+			if isDirectPossibleType(oldIndex, abstractType, objectType) && !isDirectPossibleType(newIndex, abstractType, objectType) {
+				// reported as a removed union member or interface
+				continue
+			}
+			//Fragments on the abstract type selecting fields of the object type no longer match it.
+			changes = append(changes, &Change{
+				changeType:       PossibleTypeRemoved,
+				criticalityLevel: defaultCriticality(PossibleTypeRemoved),
+				message:          fmt.Sprintf("'%s' is no longer a possible type of '%s'", objectType, abstractType),
+				path:             abstractType,
+				position:         definition.Position,
+				oldPosition:      oldIndex.types[abstractType].Position,
+				newPosition:      definition.Position,
+			})
+		}
+		for _, objectType := range sortedKeys(newPossible[abstractType]) {
+			if oldPossible[abstractType][objectType] || !isObjectType(oldIndex, objectType) {
+				continue
+			}
+			if isDirectPossibleType(newIndex, abstractType, objectType) && !isDirectPossibleType(oldIndex, abstractType, objectType) {
+				// reported as an added union member or interface
+				continue
+			}
+			//Clients which weren't programming defensively may not handle the new possible type.
+			changes = append(changes, &Change{
+				changeType:       PossibleTypeAdded,
+				criticalityLevel: defaultCriticality(PossibleTypeAdded),
+				message:          fmt.Sprintf("'%s' is a new possible type of '%s'", objectType, abstractType),
+				path:             abstractType,
+				position:         definition.Position,
+				oldPosition:      oldIndex.types[abstractType].Position,
+				newPosition:      definition.Position,
+			})
+		}
+	}
+	return changes
+}
+
+// isObjectType tells whether the type is an object type of the schema, changes of types added, removed or changing kind are reported on their own
+func isObjectType(index *schemaIndex, name string) bool {
+	definition, ok := index.types[name]
+	return ok && definition.Kind == ast.Object
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,177 @@
+package compare
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestCompareInterfaceInheritance(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldSchema   string
+		newSchema   string
+		changeTypes []ChangeType
+		criticality []Criticality
+	}{
+		{
+			name: "Interface no longer implements an interface",
+			oldSchema: `
+			interface Node { id: ID! }
+			interface Resource implements Node { id: ID! url: String }
+			`,
+			newSchema: `
+			interface Node { id: ID! }
+			interface Resource { id: ID! url: String }
+			`,
+			changeTypes: []ChangeType{InterfaceTypeInterfaceRemoved},
+			criticality: []Criticality{Breaking},
+		},
+		{
+			name: "Interface implements a new interface",
+			oldSchema: `
+			interface Node { id: ID! }
+			interface Resource { id: ID! url: String }
+			`,
+			newSchema: `
+			interface Node { id: ID! }
+			interface Resource implements Node { id: ID! url: String }
+			`,
+			changeTypes: []ChangeType{InterfaceTypeInterfaceAdded},
+			criticality: []Criticality{Dangerous},
+		},
+		{
+			name: "Implementor lost through an inherited interface",
+			oldSchema: `
+			interface Node { id: ID! }
+			interface Resource implements Node { id: ID! url: String }
+			type Image implements Resource { id: ID! url: String }
+			`,
+			newSchema: `
+			interface Node { id: ID! }
+			interface Resource { id: ID! url: String }
+			type Image implements Resource { id: ID! url: String }
+			`,
+			changeTypes: []ChangeType{InterfaceTypeInterfaceRemoved, PossibleTypeRemoved},
+			criticality: []Criticality{Breaking, Breaking},
+		},
+		{
+			name: "Implementor gained through an inherited interface",
+			oldSchema: `
+			interface Node { id: ID! }
+			interface Resource { id: ID! url: String }
+			type Image implements Resource { id: ID! url: String }
+			`,
+			newSchema: `
+			interface Node { id: ID! }
+			interface Resource implements Node { id: ID! url: String }
+			type Image implements Resource { id: ID! url: String }
+			`,
+			changeTypes: []ChangeType{InterfaceTypeInterfaceAdded, PossibleTypeAdded},
+			criticality: []Criticality{Dangerous, Dangerous},
+		},
+		{
+			name: "Implementor declaring the inherited interface keeps it",
+			oldSchema: `
+			interface Node { id: ID! }
+			interface Resource implements Node { id: ID! url: String }
+			type Image implements Resource & Node { id: ID! url: String }
+			`,
+			newSchema: `
+			interface Node { id: ID! }
+			interface Resource { id: ID! url: String }
+			type Image implements Resource & Node { id: ID! url: String }
+			`,
+			changeTypes: []ChangeType{InterfaceTypeInterfaceRemoved},
+			criticality: []Criticality{Breaking},
+		},
+		{
+			name: "Object no longer implementing an interface is only reported once",
+			oldSchema: `
+			interface Node { id: ID! }
+			type Image implements Node { id: ID! }
+			`,
+			newSchema: `
+			interface Node { id: ID! }
+			type Image { id: ID! }
+			`,
+			changeTypes: []ChangeType{ObjectTypeInterfaceRemoved},
+			criticality: []Criticality{Breaking},
+		},
+		{
+			name: "Removed union member is only reported once",
+			oldSchema: `
+			union Body = Image | Text
+			type Image { url: String }
+			type Text { body: String }
+			`,
+			newSchema: `
+			union Body = Image
+			type Image { url: String }
+			type Text { body: String }
+			`,
+			changeTypes: []ChangeType{UnionMemberRemoved},
+			criticality: []Criticality{Breaking},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema)
+			if len(changes) != len(tt.changeTypes) {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			for i, changeType := range tt.changeTypes {
+				if changes[i].changeType != changeType || changes[i].criticalityLevel != tt.criticality[i] {
+					t.Errorf("change = %v, want %v %v", changes[i], changeType, tt.criticality[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPossibleTypes(t *testing.T) {
+	schema, err := parser.ParseSchema(&ast.Source{Input: `
+	interface Node { id: ID! }
+	interface Resource implements Node { id: ID! }
+	interface Media implements Resource & Node { id: ID! }
+	type Image implements Media & Resource & Node { id: ID! }
+	type User implements Node { id: ID! }
+	union Result = Image | User
+	`})
+	if err != nil {
+		t.Fatalf("error parsing schema, error = %v", err)
+	}
+	possible := possibleTypes(newSchemaIndex(schema))
+	tests := []struct {
+		abstractType string
+		want         []string
+	}{
+		{abstractType: "Node", want: []string{"Image", "User"}},
+		{abstractType: "Resource", want: []string{"Image"}},
+		{abstractType: "Media", want: []string{"Image"}},
+		{abstractType: "Result", want: []string{"Image", "User"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.abstractType, func(t *testing.T) {
+			if got := sortedKeys(possible[tt.abstractType]); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("possibleTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
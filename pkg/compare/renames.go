@@ -0,0 +1,351 @@
+package compare
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// DefaultRenameThreshold is the similarity a removed and an added element need to be reported as renamed
+const DefaultRenameThreshold = 0.8
+
+// WithRenameDetection pairs removed and added types, fields, arguments and enum values of the same parent into one
+// renamed change when their similarity, between 0 and 1, is at least the threshold. Renames are still breaking changes.
+func WithRenameDetection(threshold float64) Option {
+	return func(o *options) {
+		o.renameThreshold = threshold
+	}
+}
+
+// renameCandidate is a removed element paired with an added one
+type renameCandidate struct {
+	removedType ChangeType
+	removedPath string
+	addedType   ChangeType
+	addedPath   string
+	similarity  float64
+	rename      *Change
+}
+
+// detectRenames replaces the removed and added changes of the renamed elements with renamed changes
+func detectRenames(changes []*Change, oldSchema *ast.SchemaDocument, newSchema *ast.SchemaDocument, threshold float64) []*Change {
+	var candidates []renameCandidate
+	candidates = append(candidates, typeRenameCandidates(oldSchema.Definitions, newSchema.Definitions)...)
+	for _, ot := range oldSchema.Definitions {
+		nt := newSchema.Definitions.ForName(ot.Name)
+		if nt == nil || nt.Kind != ot.Kind {
+			continue
+		}
+		switch ot.Kind {
+		case ast.Enum:
+			candidates = append(candidates, enumValueRenameCandidates(ot, nt)...)
+		case ast.Object, ast.Interface, ast.InputObject:
+			candidates = append(candidates, fieldRenameCandidates(ot, nt)...)
+		}
+	}
+
+	// the most similar pairs are matched first, every element is renamed at most once
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].similarity > candidates[j].similarity
+	})
+	byKey := map[string]int{}
+	for i, c := range changes {
+		byKey[changeKey(c.changeType, c.path)] = i
+	}
+	removedChanges := map[int]bool{}
+	renamedChanges := map[int]*Change{}
+	for _, candidate := range candidates {
+		if candidate.similarity < threshold {
+			break
+		}
+		removedIndex, removedFound := byKey[changeKey(candidate.removedType, candidate.removedPath)]
+		addedIndex, addedFound := byKey[changeKey(candidate.addedType, candidate.addedPath)]
+		if !removedFound || !addedFound || removedChanges[removedIndex] || removedChanges[addedIndex] {
+			continue
+		}
+		removedChanges[removedIndex] = true
+		removedChanges[addedIndex] = true
+		renamedChanges[removedIndex] = candidate.rename
+	}
+
+	renamed := make([]*Change, 0, len(changes))
+	for i, c := range changes {
+		if rename, ok := renamedChanges[i]; ok {
+			renamed = append(renamed, rename)
+			continue
+		}
+		if !removedChanges[i] {
+			renamed = append(renamed, c)
+		}
+	}
+	return renamed
+}
+
+func changeKey(changeType ChangeType, path string) string {
+	return fmt.Sprintf("%s %s", changeType, path)
+}
+
+func typeRenameCandidates(oDefs ast.DefinitionList, nDefs ast.DefinitionList) []renameCandidate {
+	var candidates []renameCandidate
+	for oi, ot := range oDefs {
+		if nDefs.ForName(ot.Name) != nil {
+			continue
+		}
+		for ni, nt := range nDefs {
+			if oDefs.ForName(nt.Name) != nil || nt.Kind != ot.Kind {
+				continue
+			}
+			similarity := 0.4*setSimilarity(typeMembers(ot), typeMembers(nt)) +
+				0.3*stringSimilarity(ot.Name, nt.Name) +
+				0.15*stringSimilarity(ot.Description, nt.Description) +
+				0.15*indexSimilarity(oi, ni, len(oDefs), len(nDefs))
+			candidates = append(candidates, renameCandidate{
+				removedType: TypeRemoved,
+				removedPath: ot.Name,
+				addedType:   TypeAdded,
+				addedPath:   nt.Name,
+				similarity:  similarity,
+				rename: &Change{
+					changeType:       TypeRenamed,
+					criticalityLevel: defaultCriticality(TypeRenamed),
+					message:          fmt.Sprintf("Type '%s' was renamed to '%s' (similarity %.2f)", ot.Name, nt.Name, similarity),
+					path:             ot.Name,
+					position:         nt.Position,
+					oldPosition:      ot.Position,
+					newPosition:      nt.Position,
+				},
+			})
+		}
+	}
+	return candidates
+}
+
+// typeMembers are the fields with their types, enum values or union members of the type
+func typeMembers(definition *ast.Definition) []string {
+	var members []string
+	for _, field := range definition.Fields {
+		members = append(members, fmt.Sprintf("%s:%s", field.Name, field.Type.String()))
+	}
+	for _, enumValue := range definition.EnumValues {
+		members = append(members, enumValue.Name)
+	}
+	members = append(members, definition.Types...)
+	return members
+}
+
+func fieldRenameCandidates(ot *ast.Definition, nt *ast.Definition) []renameCandidate {
+	var candidates []renameCandidate
+	removedType, addedType, kind := FieldRemoved, FieldAdded, string(ot.Kind)
+	if ot.Kind == ast.InputObject {
+		removedType, addedType, kind = InputFieldRemoved, InputFieldAdded, "input object type"
+	}
+	for oi, of := range ot.Fields {
+		if nt.Fields.ForName(of.Name) != nil {
+			continue
+		}
+		for ni, nf := range nt.Fields {
+			if ot.Fields.ForName(nf.Name) != nil {
+				continue
+			}
+			similarity := 0.3*typeSimilarity(of.Type, nf.Type) +
+				0.3*stringSimilarity(of.Name, nf.Name) +
+				0.15*stringSimilarity(of.Description, nf.Description) +
+				0.15*setSimilarity(argumentShape(of.Arguments), argumentShape(nf.Arguments)) +
+				0.1*indexSimilarity(oi, ni, len(ot.Fields), len(nt.Fields))
+			candidates = append(candidates, renameCandidate{
+				removedType: removedType,
+				removedPath: fmt.Sprintf("%s.%s", ot.Name, of.Name),
+				addedType:   addedType,
+				addedPath:   fmt.Sprintf("%s.%s", nt.Name, nf.Name),
+				similarity:  similarity,
+				rename: &Change{
+					changeType:       FieldRenamed,
+					criticalityLevel: defaultCriticality(FieldRenamed),
+					message:          fmt.Sprintf("Field '%s.%s' was renamed to '%s.%s' in %s (similarity %.2f)", ot.Name, of.Name, nt.Name, nf.Name, kind, similarity),
+					path:             fmt.Sprintf("%s.%s", ot.Name, of.Name),
+					position:         nf.Position,
+					oldPosition:      of.Position,
+					newPosition:      nf.Position,
+				},
+			})
+		}
+	}
+	for _, of := range ot.Fields {
+		if nf := nt.Fields.ForName(of.Name); nf != nil {
+			candidates = append(candidates, argumentRenameCandidates(ot.Name, of, nf)...)
+		}
+	}
+	return candidates
+}
+
+// argumentShape are the arguments with their types
+func argumentShape(arguments ast.ArgumentDefinitionList) []string {
+	var shape []string
+	for _, argument := range arguments {
+		shape = append(shape, fmt.Sprintf("%s:%s", argument.Name, argument.Type.String()))
+	}
+	return shape
+}
+
+func argumentRenameCandidates(typeName string, of *ast.FieldDefinition, nf *ast.FieldDefinition) []renameCandidate {
+	var candidates []renameCandidate
+	for oi, oArg := range of.Arguments {
+		if nf.Arguments.ForName(oArg.Name) != nil {
+			continue
+		}
+		for ni, nArg := range nf.Arguments {
+			if of.Arguments.ForName(nArg.Name) != nil {
+				continue
+			}
+			defaultSimilarity := 0.0
+			if normalizeValue(oArg.DefaultValue) == normalizeValue(nArg.DefaultValue) {
+				defaultSimilarity = 1
+			}
+			similarity := 0.35*typeSimilarity(oArg.Type, nArg.Type) +
+				0.3*stringSimilarity(oArg.Name, nArg.Name) +
+				0.15*stringSimilarity(oArg.Description, nArg.Description) +
+				0.1*defaultSimilarity +
+				0.1*indexSimilarity(oi, ni, len(of.Arguments), len(nf.Arguments))
+			candidates = append(candidates, renameCandidate{
+				removedType: FieldArgumentRemoved,
+				removedPath: fmt.Sprintf("%s.%s.%s", typeName, of.Name, oArg.Name),
+				addedType:   FieldArgumentAdded,
+				addedPath:   fmt.Sprintf("%s.%s.%s", typeName, nf.Name, nArg.Name),
+				similarity:  similarity,
+				rename: &Change{
+					changeType:       ArgumentRenamed,
+					criticalityLevel: defaultCriticality(ArgumentRenamed),
+					message:          fmt.Sprintf("Argument '%s' was renamed to '%s' in '%s.%s' (similarity %.2f)", oArg.Name, nArg.Name, typeName, of.Name, similarity),
+					path:             fmt.Sprintf("%s.%s.%s", typeName, of.Name, oArg.Name),
+					position:         nArg.Position,
+					oldPosition:      oArg.Position,
+					newPosition:      nArg.Position,
+				},
+			})
+		}
+	}
+	return candidates
+}
+
+func enumValueRenameCandidates(ot *ast.Definition, nt *ast.Definition) []renameCandidate {
+	var candidates []renameCandidate
+	for oi, ov := range ot.EnumValues {
+		if nt.EnumValues.ForName(ov.Name) != nil {
+			continue
+		}
+		for ni, nv := range nt.EnumValues {
+			if ot.EnumValues.ForName(nv.Name) != nil {
+				continue
+			}
+			similarity := 0.5*stringSimilarity(ov.Name, nv.Name) +
+				0.3*stringSimilarity(ov.Description, nv.Description) +
+				0.2*indexSimilarity(oi, ni, len(ot.EnumValues), len(nt.EnumValues))
+			candidates = append(candidates, renameCandidate{
+				removedType: EnumValueRemoved,
+				removedPath: fmt.Sprintf("%s.%s", ot.Name, ov.Name),
+				addedType:   EnumValueAdded,
+				addedPath:   fmt.Sprintf("%s.%s", nt.Name, nv.Name),
+				similarity:  similarity,
+				rename: &Change{
+					changeType:       EnumValueRenamed,
+					criticalityLevel: defaultCriticality(EnumValueRenamed),
+					message:          fmt.Sprintf("Enum value '%s' was renamed to '%s' in enum '%s' (similarity %.2f)", ov.Name, nv.Name, ot.Name, similarity),
+					path:             fmt.Sprintf("%s.%s", ot.Name, ov.Name),
+					position:         nv.Position,
+					oldPosition:      ov.Position,
+					newPosition:      nv.Position,
+				},
+			})
+		}
+	}
+	return candidates
+}
+
+// typeSimilarity is 1 for the same types, 0.5 when only the nullability or list wrapping differs
+func typeSimilarity(otyp *ast.Type, ntyp *ast.Type) float64 {
+	switch {
+	case otyp.String() == ntyp.String():
+		return 1
+	case otyp.Name() == ntyp.Name():
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// stringSimilarity is 1 minus the case-insensitive edit distance relative to the longer string, empty strings are equal
+func stringSimilarity(a string, b string) float64 {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	longest := utf8.RuneCountInString(a)
+	if l := utf8.RuneCountInString(b); l > longest {
+		longest = l
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein([]rune(a), []rune(b)))/float64(longest)
+}
+
+// levenshtein returns the number of single rune edits to change a into b
+func levenshtein(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// setSimilarity is the Jaccard index of the two sets, empty sets are equal
+func setSimilarity(a []string, b []string) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	set := map[string]int{}
+	for _, item := range a {
+		set[item] |= 1
+	}
+	for _, item := range b {
+		set[item] |= 2
+	}
+	common := 0
+	for _, in := range set {
+		if in == 3 {
+			common++
+		}
+	}
+	return float64(common) / float64(len(set))
+}
+
+// indexSimilarity is 1 when the elements are at the same index in their parents, less the further apart they are
+func indexSimilarity(oi int, ni int, oLen int, nLen int) float64 {
+	longest := oLen
+	if nLen > longest {
+		longest = nLen
+	}
+	distance := oi - ni
+	if distance < 0 {
+		distance = -distance
+	}
+	return 1 - float64(distance)/float64(longest)
+}
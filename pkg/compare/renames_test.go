@@ -0,0 +1,250 @@
+package compare
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestCompareRenames(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldSchema   string
+		newSchema   string
+		threshold   float64
+		changeTypes []ChangeType
+		path        string
+	}{
+		{
+			name: "Field renamed",
+			oldSchema: `
+			type Book {
+				isbn: String!
+				title: String
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+				title: String
+			}
+			`,
+			threshold:   DefaultRenameThreshold,
+			changeTypes: []ChangeType{FieldRenamed},
+			path:        "Book.isbn",
+		},
+		{
+			name: "Field renamed below the threshold",
+			oldSchema: `
+			type Book {
+				isbn: String!
+				title: String
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+				title: String
+			}
+			`,
+			threshold:   0.95,
+			changeTypes: []ChangeType{FieldRemoved, FieldAdded},
+		},
+		{
+			name: "Unrelated field replaced",
+			oldSchema: `
+			type Book {
+				isbn: String!
+				year: Int
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn: String!
+				pages: [String]
+			}
+			`,
+			threshold:   DefaultRenameThreshold,
+			changeTypes: []ChangeType{FieldRemoved, FieldAdded},
+		},
+		{
+			name: "Field renamed with arguments and description",
+			oldSchema: `
+			type Query {
+				"books of the library"
+				books(first: Int, after: String): [String]
+				authors: [String]
+			}
+			`,
+			newSchema: `
+			type Query {
+				"all books of the library"
+				allBooks(first: Int, after: String): [String]
+				authors: [String]
+			}
+			`,
+			threshold:   DefaultRenameThreshold,
+			changeTypes: []ChangeType{FieldRenamed},
+			path:        "Query.books",
+		},
+		{
+			name: "Input field renamed",
+			oldSchema: `
+			input BookInput {
+				isbn: String!
+				title: String
+			}
+			`,
+			newSchema: `
+			input BookInput {
+				isbn: String!
+				bookTitle: String
+			}
+			`,
+			threshold:   DefaultRenameThreshold,
+			changeTypes: []ChangeType{FieldRenamed},
+			path:        "BookInput.title",
+		},
+		{
+			name: "Type renamed",
+			oldSchema: `
+			type Book {
+				isbn: String!
+				title: String
+			}
+			`,
+			newSchema: `
+			type Books {
+				isbn: String!
+				title: String
+			}
+			`,
+			threshold:   DefaultRenameThreshold,
+			changeTypes: []ChangeType{TypeRenamed},
+			path:        "Book",
+		},
+		{
+			name: "Type of another kind added",
+			oldSchema: `
+			type Book {
+				isbn: String!
+			}
+			`,
+			newSchema: `
+			input Books {
+				isbn: String!
+			}
+			`,
+			threshold:   DefaultRenameThreshold,
+			changeTypes: []ChangeType{TypeRemoved, TypeAdded},
+		},
+		{
+			name: "Enum value renamed",
+			oldSchema: `
+			enum Status {
+				AVAILABLE
+				IN_STOCK
+			}
+			`,
+			newSchema: `
+			enum Status {
+				AVAILABLE
+				INSTOCK
+			}
+			`,
+			threshold:   DefaultRenameThreshold,
+			changeTypes: []ChangeType{EnumValueRenamed},
+			path:        "Status.IN_STOCK",
+		},
+		{
+			name: "Argument renamed",
+			oldSchema: `
+			type Query {
+				books(orderBy: String, first: Int): [String]
+			}
+			`,
+			newSchema: `
+			type Query {
+				books(sortBy: String, first: Int): [String]
+			}
+			`,
+			threshold:   DefaultRenameThreshold,
+			changeTypes: []ChangeType{ArgumentRenamed},
+			path:        "Query.books.orderBy",
+		},
+		{
+			name: "Renames are not detected without the option",
+			oldSchema: `
+			type Book {
+				isbn: String!
+			}
+			`,
+			newSchema: `
+			type Book {
+				isbn13: String!
+			}
+			`,
+			changeTypes: []ChangeType{FieldRemoved, FieldAdded},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			var opts []Option
+			if tt.threshold > 0 {
+				opts = append(opts, WithRenameDetection(tt.threshold))
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema, opts...)
+			if len(changes) != len(tt.changeTypes) {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			for i, changeType := range tt.changeTypes {
+				if changes[i].changeType != changeType {
+					t.Errorf("change type = %v, want %v", changes[i].changeType, changeType)
+				}
+			}
+			if len(tt.path) != 0 {
+				if changes[0].criticalityLevel != Breaking || changes[0].path != tt.path {
+					t.Errorf("Renamed change = %v", changes[0])
+				}
+			}
+		})
+	}
+}
+
+func TestStringSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want float64
+	}{
+		{name: "equal", a: "isbn", b: "isbn", want: 1},
+		{name: "empty", a: "", b: "", want: 1},
+		{name: "case insensitive", a: "ISBN", b: "isbn", want: 1},
+		{name: "suffix added", a: "isbn", b: "isbn13", want: 1 - 2.0/6},
+		{name: "different", a: "abc", b: "xyz", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stringSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("stringSimilarity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
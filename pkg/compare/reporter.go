@@ -0,0 +1,183 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Reporter reports the changes found comparing two schemas, e.g. as text, JSON, Markdown or JUnit
+type Reporter interface {
+	// Begin is called once before any change is reported
+	Begin() error
+	// Report is called for every change, ordered by criticality from the most severe one and then by position, the order
+	// the text, Markdown and JUnit reporters group the changes in
+	Report(change *Change) error
+	// End is called once after all the changes were reported, with the stale entries of the accepted changes file
+	End(stale []*StaleAcceptedChange) error
+}
+
+// WriteReport reports all the changes and the stale entries of the accepted changes file with the reporter
+func WriteReport(reporter Reporter, changes []*Change, stale []*StaleAcceptedChange) error {
+	if err := reporter.Begin(); err != nil {
+		return err
+	}
+	for _, c := range SortChanges(changes) {
+		if err := reporter.Report(c); err != nil {
+			return err
+		}
+	}
+	return reporter.End(stale)
+}
+
+// ParseFailOn parses the names of the criticalities whose changes fail the compare e.g. Breaking,Dangerous
+func ParseFailOn(names []string) ([]Criticality, error) {
+	failOn := make([]Criticality, 0, len(names))
+	for _, name := range names {
+		criticality, err := ParseCriticality(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		failOn = append(failOn, criticality)
+	}
+	return failOn, nil
+}
+
+// ExitStatus returns the exit status of the compare, 1 when any of the changes has one of the fail-on criticalities
+func ExitStatus(changes []*Change, failOn []Criticality) int {
+	for _, c := range changes {
+		for _, criticality := range failOn {
+			if c.criticalityLevel == criticality {
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// SortChanges returns a copy of the changes ordered by criticality, the most severe first, and then by position
+func SortChanges(changes []*Change) []*Change {
+	sorted := make([]*Change, len(changes))
+	copy(sorted, changes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].criticalityLevel != sorted[j].criticalityLevel {
+			return sorted[i].criticalityLevel.severity() > sorted[j].criticalityLevel.severity()
+		}
+		return less(sorted)(i, j)
+	})
+	return sorted
+}
+
+// criticalityIcons are the icons the changes of every criticality are printed with
+var criticalityIcons = map[Criticality]string{
+	Breaking:    "❌",
+	Dangerous:   "✋️",
+	SafeUnused:  "🗑️",
+	Accepted:    "☑️",
+	NonBreaking: "✅",
+}
+
+// TextReporter prints the changes grouped by criticality, followed by the number of breaking changes
+type TextReporter struct {
+	// WithFilepath prints the old and new position of every change
+	WithFilepath bool
+	// Snippets prints the source lines of the old and new schema around every change
+	Snippets bool
+	// BreakingOnly prints only the breaking changes, the other changes are still counted as changes
+	BreakingOnly bool
+
+	w       io.Writer
+	changes []*Change
+}
+
+// NewTextReporter creates a text reporter writing to w
+func NewTextReporter(w io.Writer) *TextReporter {
+	return &TextReporter{w: w}
+}
+
+// stdoutTextReporter is the text reporter of the deprecated Report functions, which print to stdout
+func stdoutTextReporter(withFilepath bool) *TextReporter {
+	reporter := NewTextReporter(os.Stdout)
+	reporter.WithFilepath = withFilepath
+	return reporter
+}
+
+// Begin resets the changes of a previous report
+func (r *TextReporter) Begin() error {
+	r.changes = nil
+	return nil
+}
+
+// Report collects the change, changes are printed grouped by criticality at the end
+func (r *TextReporter) Report(change *Change) error {
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+// End prints the changes, the number of breaking changes and the stale accepted changes
+func (r *TextReporter) End(stale []*StaleAcceptedChange) error {
+	if len(r.changes) == 0 {
+		fmt.Fprintln(r.w, "No changes found on schema compare!")
+	} else {
+		groups := GroupChanges(r.changes)
+		breakingCount := 0
+		for _, criticality := range criticalitiesBySeverity() {
+			if r.BreakingOnly && criticality != Breaking {
+				continue
+			}
+			count := r.printChanges(criticalityIcons[criticality], groups[criticality])
+			if criticality == Breaking {
+				breakingCount = count
+			}
+		}
+		if breakingCount == 0 {
+			fmt.Fprintln(r.w, "No breaking changes found 🎉")
+		} else {
+			fmt.Fprintf(r.w, "\n❌ Breaking changes in schema: %d\n", breakingCount)
+		}
+	}
+	if len(stale) != 0 {
+		fmt.Fprintln(r.w)
+		r.printStaleAcceptedChanges(stale)
+	}
+	return nil
+}
+
+// printChanges prints the changes ordered by position with the icon of their criticality, along with the operations they
+// break and the justification of their acceptance
+func (r *TextReporter) printChanges(icon string, changes []*Change) int {
+	if len(changes) == 0 {
+		return 0
+	}
+	sort.Slice(changes, less(changes))
+	for _, c := range changes {
+		if pos := getPositions(c); r.WithFilepath && len(pos) > 0 {
+			fmt.Fprintf(r.w, "%s  %s %s\n", icon, pos, c.message)
+		} else {
+			fmt.Fprintf(r.w, "%s  %s\n", icon, c.message)
+		}
+		if len(c.operations) != 0 {
+			fmt.Fprintf(r.w, "    breaks: %s\n", strings.Join(c.operations, ", "))
+		}
+		if c.accepted != nil {
+			fmt.Fprintf(r.w, "    accepted: %s\n", acceptedText(c))
+		}
+		if r.Snippets {
+			writeSnippets(r.w, c)
+		}
+	}
+	return len(changes)
+}
+
+func (r *TextReporter) printStaleAcceptedChanges(stale []*StaleAcceptedChange) int {
+	for _, s := range stale {
+		if s.Criticality != nil {
+			fmt.Fprintf(r.w, "%s  accepted change %s '%s' is stale: %s, the %s change is no longer accepted\n", "⚠️", s.ChangeType, s.Path, s.Reason, s.Criticality)
+			continue
+		}
+		fmt.Fprintf(r.w, "%s  accepted change %s '%s' is stale: %s\n", "⚠️", s.ChangeType, s.Path, s.Reason)
+	}
+	return len(stale)
+}
@@ -0,0 +1,227 @@
+package compare
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// reporterChanges are the changes between two versions of a schema with a breaking, a dangerous and a non-breaking change
+func reporterChanges(t *testing.T) []*Change {
+	oldSchema, err := parser.ParseSchema(&ast.Source{
+		Name: "old.graphql",
+		Input: `type Book {
+  isbn: String!
+  title: String
+}
+union Result = Book`,
+	})
+	if err != nil {
+		t.Fatalf("error parsing old schema, error = %v", err)
+	}
+	newSchema, err := parser.ParseSchema(&ast.Source{
+		Name: "new.graphql",
+		Input: `type Book {
+  isbn: String!
+  author: String
+}
+type Author { name: String }
+union Result = Book | Author`,
+	})
+	if err != nil {
+		t.Fatalf("error parsing new schema, error = %v", err)
+	}
+	return FindChangesInSchemas(oldSchema, newSchema)
+}
+
+// recordingReporter records the calls of the reporter hooks
+type recordingReporter struct {
+	calls []string
+}
+
+func (r *recordingReporter) Begin() error {
+	r.calls = append(r.calls, "begin")
+	return nil
+}
+
+func (r *recordingReporter) Report(change *Change) error {
+	r.calls = append(r.calls, string(change.changeType))
+	return nil
+}
+
+func (r *recordingReporter) End(stale []*StaleAcceptedChange) error {
+	r.calls = append(r.calls, "end")
+	return nil
+}
+
+func TestWriteReport(t *testing.T) {
+	reporter := &recordingReporter{}
+	if err := WriteReport(reporter, reporterChanges(t), nil); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	want := []string{"begin", string(FieldRemoved), string(UnionMemberAdded), string(FieldAdded), string(TypeAdded), "end"}
+	if !reflect.DeepEqual(reporter.calls, want) {
+		t.Errorf("WriteReport() calls = %v, want %v", reporter.calls, want)
+	}
+}
+
+func TestCriticalitiesBySeverity(t *testing.T) {
+	want := []Criticality{Breaking, Dangerous, SafeUnused, Accepted, NonBreaking}
+	if got := criticalitiesBySeverity(); !reflect.DeepEqual(got, want) {
+		t.Errorf("criticalitiesBySeverity() = %v, want %v", got, want)
+	}
+}
+
+func TestParseFailOn(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []Criticality
+		wantErr bool
+	}{
+		{name: "Criticalities matched case-insensitively", names: []string{"breaking", " Dangerous"}, want: []Criticality{Breaking, Dangerous}},
+		{name: "No criticalities", names: []string{}, want: []Criticality{}},
+		{name: "Unknown criticality", names: []string{"Breaking", "Risky"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFailOn(tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFailOn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFailOn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		failOn []Criticality
+		want   int
+	}{
+		{name: "Breaking change fails", failOn: []Criticality{Breaking}, want: 1},
+		{name: "Dangerous change fails", failOn: []Criticality{Dangerous}, want: 1},
+		{name: "No change of the fail-on criticalities", failOn: []Criticality{SafeUnused, Accepted}, want: 0},
+		{name: "No fail-on criticalities", failOn: nil, want: 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitStatus(reporterChanges(t), tt.failOn); got != tt.want {
+				t.Errorf("ExitStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextReporter(t *testing.T) {
+	stale := []*StaleAcceptedChange{{AcceptedChange: &AcceptedChange{ChangeType: TypeRemoved, Path: "Shelf"}, Reason: "no change matches it"}}
+	tests := []struct {
+		name     string
+		reporter func(buf *bytes.Buffer) *TextReporter
+		changes  []*Change
+		stale    []*StaleAcceptedChange
+		want     string
+	}{
+		{
+			name: "Changes with positions",
+			reporter: func(buf *bytes.Buffer) *TextReporter {
+				reporter := NewTextReporter(buf)
+				reporter.WithFilepath = true
+				return reporter
+			},
+			changes: reporterChanges(t),
+			want: `❌  old.graphql:3 Field 'Book.title' was removed from OBJECT
+✋️  old.graphql:5 → new.graphql:6 Member 'Author' was added to Union type 'Result'
+✅  new.graphql:3 Field 'Book.author' was added to OBJECT
+✅  new.graphql:5 Type 'Author' was added
+
+❌ Breaking changes in schema: 1
+`,
+		},
+		{
+			name: "Breaking changes only with snippets",
+			reporter: func(buf *bytes.Buffer) *TextReporter {
+				reporter := NewTextReporter(buf)
+				reporter.Snippets = true
+				reporter.BreakingOnly = true
+				return reporter
+			},
+			changes: reporterChanges(t),
+			want: `❌  Field 'Book.title' was removed from OBJECT
+    old.graphql:3
+      2 |   isbn: String!
+    > 3 |   title: String
+      4 | }
+
+❌ Breaking changes in schema: 1
+`,
+		},
+		{
+			name: "Criticalities ordered by severity",
+			reporter: func(buf *bytes.Buffer) *TextReporter {
+				return NewTextReporter(buf)
+			},
+			changes: []*Change{
+				{changeType: FieldAdded, criticalityLevel: NonBreaking, message: "Field 'Book.author' was added to OBJECT", path: "Book.author", position: &ast.Position{Line: 1, Src: &ast.Source{}}},
+				{changeType: FieldRemoved, criticalityLevel: SafeUnused, message: "Field 'Book.year' was removed from OBJECT", path: "Book.year", position: &ast.Position{Line: 2, Src: &ast.Source{}}},
+				{changeType: EnumValueAdded, criticalityLevel: Dangerous, message: "Enum value 'LENT' was added to enum 'Status'", path: "Status.LENT", position: &ast.Position{Line: 3, Src: &ast.Source{}}},
+			},
+			want: `✋️  Enum value 'LENT' was added to enum 'Status'
+🗑️  Field 'Book.year' was removed from OBJECT
+✅  Field 'Book.author' was added to OBJECT
+No breaking changes found 🎉
+`,
+		},
+		{
+			name: "No changes with stale accepted changes",
+			reporter: func(buf *bytes.Buffer) *TextReporter {
+				return NewTextReporter(buf)
+			},
+			stale: stale,
+			want: `No changes found on schema compare!
+
+⚠️  accepted change TYPE_REMOVED 'Shelf' is stale: no change matches it
+`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteReport(tt.reporter(&buf), tt.changes, tt.stale); err != nil {
+				t.Fatalf("WriteReport() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("TextReporter output =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(NewJSONReporter(&buf), reporterChanges(t), nil); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	var report struct {
+		Version int `json:"version"`
+		Changes []struct {
+			ChangeType string `json:"changeType"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid json report, error = %v", err)
+	}
+	if report.Version != JSONReportVersion || len(report.Changes) != 4 || report.Changes[0].ChangeType != string(FieldRemoved) {
+		t.Errorf("Unexpected report = %+v", report)
+	}
+}
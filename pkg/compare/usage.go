@@ -0,0 +1,156 @@
+package compare
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// typeUsage tells whether a named type is used in input positions, output positions or both
+type typeUsage int
+
+const (
+	unusedType  typeUsage = 0
+	inputUsage  typeUsage = 1
+	outputUsage typeUsage = 2
+)
+
+func (u typeUsage) String() string {
+	switch u {
+	case inputUsage:
+		return "input"
+	case outputUsage:
+		return "output"
+	case inputUsage | outputUsage:
+		return "input and output"
+	default:
+		return "unused"
+	}
+}
+
+// typeUsageIndex holds the usage of every named type reachable from the root operation types or used by directive arguments
+type typeUsageIndex struct {
+	usage map[string]typeUsage
+	// roots are the root operation types each type is reachable from
+	roots map[string]map[string]bool
+}
+
+// typeUsageWalker walks the types reachable from one root operation type, or from the directive definitions when root is empty
+type typeUsageWalker struct {
+	schema          *schemaIndex
+	index           *typeUsageIndex
+	implementations map[string][]string
+	root            string
+	visited         map[string]typeUsage
+}
+
+// newTypeUsageIndex builds the usage of the named types of all the schemas, a type used as input in one schema and as output in another is used as both
+func newTypeUsageIndex(schemas ...*ast.SchemaDocument) *typeUsageIndex {
+	index := &typeUsageIndex{
+		usage: map[string]typeUsage{},
+		roots: map[string]map[string]bool{},
+	}
+	for _, schema := range schemas {
+		schemaIndex := newSchemaIndex(schema)
+		implementations := map[string][]string{}
+		for _, definition := range schemaIndex.types {
+			for _, name := range definition.Interfaces {
+				implementations[name] = append(implementations[name], definition.Name)
+			}
+		}
+		for _, operation := range []ast.Operation{ast.Query, ast.Mutation, ast.Subscription} {
+			root := schemaIndex.rootTypes[operation]
+			walker := &typeUsageWalker{schema: schemaIndex, index: index, implementations: implementations, root: root, visited: map[string]typeUsage{}}
+			walker.walk(root, outputUsage)
+		}
+		walker := &typeUsageWalker{schema: schemaIndex, index: index, implementations: implementations, visited: map[string]typeUsage{}}
+		for _, directive := range schema.Directives {
+			walker.arguments(directive.Arguments)
+		}
+	}
+	return index
+}
+
+func (w *typeUsageWalker) walk(typeName string, usage typeUsage) {
+	definition, ok := w.schema.types[typeName]
+	if !ok || w.visited[typeName]&usage == usage {
+		return
+	}
+	w.visited[typeName] |= usage
+	w.index.usage[typeName] |= usage
+	if len(w.root) != 0 {
+		if _, ok := w.index.roots[typeName]; !ok {
+			w.index.roots[typeName] = map[string]bool{}
+		}
+		w.index.roots[typeName][w.root] = true
+	}
+	switch definition.Kind {
+	case ast.Object, ast.Interface:
+		for _, field := range definition.Fields {
+			w.walk(field.Type.Name(), outputUsage)
+			w.arguments(field.Arguments)
+		}
+		for _, implementation := range w.implementations[typeName] {
+			w.walk(implementation, outputUsage)
+		}
+	case ast.Union:
+		for _, member := range definition.Types {
+			w.walk(member, outputUsage)
+		}
+	case ast.InputObject:
+		for _, field := range definition.Fields {
+			w.walk(field.Type.Name(), inputUsage)
+		}
+	}
+}
+
+func (w *typeUsageWalker) arguments(arguments ast.ArgumentDefinitionList) {
+	for _, argument := range arguments {
+		w.walk(argument.Type.Name(), inputUsage)
+	}
+}
+
+// describe names the usage of the type and the roots it is reachable from e.g. used as output from Query, Subscription
+func (index *typeUsageIndex) describe(typeName string) string {
+	usage := index.usage[typeName]
+	var roots []string
+	for root := range index.roots[typeName] {
+		roots = append(roots, root)
+	}
+	if len(roots) == 0 {
+		return fmt.Sprintf("used as %s", usage)
+	}
+	sort.Strings(roots)
+	return fmt.Sprintf("used as %s from %s", usage, strings.Join(roots, ", "))
+}
+
+// applyTypeUsage classifies the changes of enum values and union members by where their type is used: enum values added
+// to input only enums can't break clients, while values removed from output only enums can't break queries.
+// Changes of unused types keep their criticality.
+func applyTypeUsage(changes []*Change, oldSchema *ast.SchemaDocument, newSchema *ast.SchemaDocument) {
+	var index *typeUsageIndex
+	for _, change := range changes {
+		if change.changeType != EnumValueAdded && change.changeType != EnumValueRemoved && change.changeType != UnionMemberAdded {
+			continue
+		}
+		if index == nil {
+			index = newTypeUsageIndex(oldSchema, newSchema)
+		}
+		typeName := strings.Split(change.path, ".")[0]
+		usage := index.usage[typeName]
+		if usage == unusedType {
+			continue
+		}
+		switch {
+		case change.changeType == EnumValueAdded && usage == inputUsage:
+			// clients only send the values of input enums, they never receive the added one
+			change.criticalityLevel = defaultCriticalityIn(EnumValueAdded, inputEnumContext)
+		case change.changeType == EnumValueRemoved && usage == outputUsage:
+			// queries can't send values of output enums, clients only stop receiving the removed one
+			change.criticalityLevel = defaultCriticalityIn(EnumValueRemoved, outputEnumContext)
+		}
+		change.message = fmt.Sprintf("%s, %s", strings.TrimSpace(change.message), index.describe(typeName))
+	}
+}
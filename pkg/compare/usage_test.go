@@ -0,0 +1,112 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestCompareTypeUsage(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldSchema   string
+		newSchema   string
+		criticality Criticality
+		ChangeType  ChangeType
+		message     string
+	}{
+		{
+			name:        "Enum value added to an input enum",
+			oldSchema:   `type Query { books(sort: Sort): [String] } enum Sort { TITLE }`,
+			newSchema:   `type Query { books(sort: Sort): [String] } enum Sort { TITLE YEAR }`,
+			criticality: NonBreaking,
+			ChangeType:  EnumValueAdded,
+			message:     "used as input from Query",
+		},
+		{
+			name:        "Enum value added to an output enum",
+			oldSchema:   `type Query { book: Book } type Book { status: Status } enum Status { AVAILABLE }`,
+			newSchema:   `type Query { book: Book } type Book { status: Status } enum Status { AVAILABLE LENT }`,
+			criticality: Dangerous,
+			ChangeType:  EnumValueAdded,
+			message:     "used as output from Query",
+		},
+		{
+			name:        "Enum value removed from an input enum",
+			oldSchema:   `type Mutation { sort(input: SortInput): [String] } input SortInput { by: Sort } enum Sort { TITLE YEAR }`,
+			newSchema:   `type Mutation { sort(input: SortInput): [String] } input SortInput { by: Sort } enum Sort { TITLE }`,
+			criticality: Breaking,
+			ChangeType:  EnumValueRemoved,
+			message:     "used as input from Mutation",
+		},
+		{
+			name:        "Enum value removed from an output enum",
+			oldSchema:   `type Query { node: Node } interface Node { id: ID } type Book implements Node { id: ID status: Status } enum Status { AVAILABLE LENT }`,
+			newSchema:   `type Query { node: Node } interface Node { id: ID } type Book implements Node { id: ID status: Status } enum Status { AVAILABLE }`,
+			criticality: Dangerous,
+			ChangeType:  EnumValueRemoved,
+			message:     "used as output from Query",
+		},
+		{
+			name:        "Enum value removed from an enum used as input and output",
+			oldSchema:   `type Query { books(status: Status): [Book] } type Subscription { book: Book } type Book { status: Status } enum Status { AVAILABLE LENT }`,
+			newSchema:   `type Query { books(status: Status): [Book] } type Subscription { book: Book } type Book { status: Status } enum Status { AVAILABLE }`,
+			criticality: Breaking,
+			ChangeType:  EnumValueRemoved,
+			message:     "used as input and output from Query, Subscription",
+		},
+		{
+			name:        "Enum value removed from an enum used by a directive argument",
+			oldSchema:   `directive @auth(role: Role) on FIELD_DEFINITION enum Role { ADMIN READER }`,
+			newSchema:   `directive @auth(role: Role) on FIELD_DEFINITION enum Role { ADMIN }`,
+			criticality: Breaking,
+			ChangeType:  EnumValueRemoved,
+			message:     "used as input",
+		},
+		{
+			name:        "Enum value added to an unused enum",
+			oldSchema:   `type Query { books: [String] } enum Sort { TITLE }`,
+			newSchema:   `type Query { books: [String] } enum Sort { TITLE YEAR }`,
+			criticality: Dangerous,
+			ChangeType:  EnumValueAdded,
+		},
+		{
+			name:        "Union member added to an output union",
+			oldSchema:   `schema { query: Root } type Root { search: Result } union Result = Book type Book { id: ID } type Author { id: ID }`,
+			newSchema:   `schema { query: Root } type Root { search: Result } union Result = Book | Author type Book { id: ID } type Author { id: ID }`,
+			criticality: Dangerous,
+			ChangeType:  UnionMemberAdded,
+			message:     "used as output from Root",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema)
+			if len(changes) != 1 {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			if changes[0].criticalityLevel != tt.criticality || changes[0].changeType != tt.ChangeType {
+				t.Errorf("Type usage changes = %v", changes[0])
+			}
+			if !strings.HasSuffix(changes[0].message, tt.message) {
+				t.Errorf("message = %v, want it to end with %v", changes[0].message, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package compare
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// fieldSetArguments are the arguments of the known directives holding field sets, which are compared as parsed selection sets
+var fieldSetArguments = map[string]string{
+	"key":      "fields",
+	"requires": "fields",
+	"provides": "fields",
+}
+
+// ValueDiff is the difference between the old and the new value of a default value or a directive argument
+type ValueDiff struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+	// Paths are the object fields and list items whose values differ e.g. filter.year or tags[1], empty when the whole value differs
+	Paths []string `json:"paths,omitempty"`
+}
+
+// normalizeValue prints the value with the object fields sorted by name, so values with the same meaning are equal.
+// List items keep their order, since it is meaningful.
+func normalizeValue(value *ast.Value) string {
+	if value == nil {
+		return ""
+	}
+	switch value.Kind {
+	case ast.StringValue, ast.BlockValue:
+		return strconv.Quote(value.Raw)
+	case ast.FloatValue:
+		if f, err := strconv.ParseFloat(value.Raw, 64); err == nil {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		return value.Raw
+	case ast.ListValue:
+		items := make([]string, 0, len(value.Children))
+		for _, child := range value.Children {
+			items = append(items, normalizeValue(child.Value))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+	case ast.ObjectValue:
+		fields := make([]string, 0, len(value.Children))
+		for _, child := range value.Children {
+			fields = append(fields, fmt.Sprintf("%s: %s", child.Name, normalizeValue(child.Value)))
+		}
+		sort.Strings(fields)
+		return fmt.Sprintf("{%s}", strings.Join(fields, ", "))
+	default:
+		return value.String()
+	}
+}
+
+// normalizeArgumentValue normalizes the value of a directive argument, parsing the field sets of the known directives
+func normalizeArgumentValue(directive string, argument string, value *ast.Value) string {
+	if fieldSetArguments[directive] == argument && value != nil && value.Kind == ast.StringValue {
+		return strconv.Quote(normalizeFieldSet(value.Raw))
+	}
+	return normalizeValue(value)
+}
+
+// newValueDiff returns the difference between the values, nil when they have the same meaning
+func newValueDiff(oldValue *ast.Value, newValue *ast.Value) *ValueDiff {
+	if normalizeValue(oldValue) == normalizeValue(newValue) {
+		return nil
+	}
+	diff := &ValueDiff{Old: oldValue.String(), New: newValue.String()}
+	for _, path := range valueDiffPaths(oldValue, newValue, "") {
+		if len(path) != 0 {
+			diff.Paths = append(diff.Paths, path)
+		}
+	}
+	return diff
+}
+
+// valueDiffPaths returns the paths of the object fields and list items whose values differ
+func valueDiffPaths(oldValue *ast.Value, newValue *ast.Value, path string) []string {
+	if normalizeValue(oldValue) == normalizeValue(newValue) {
+		return nil
+	}
+	if oldValue == nil || newValue == nil || oldValue.Kind != newValue.Kind {
+		return []string{path}
+	}
+	var paths []string
+	switch {
+	case oldValue.Kind == ast.ObjectValue:
+		var names []string
+		for _, children := range []ast.ChildValueList{oldValue.Children, newValue.Children} {
+			for _, child := range children {
+				if !containsString(names, child.Name) {
+					names = append(names, child.Name)
+				}
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fieldPath := name
+			if len(path) != 0 {
+				fieldPath = fmt.Sprintf("%s.%s", path, name)
+			}
+			paths = append(paths, valueDiffPaths(oldValue.Children.ForName(name), newValue.Children.ForName(name), fieldPath)...)
+		}
+	case oldValue.Kind == ast.ListValue && len(oldValue.Children) == len(newValue.Children):
+		for i := range oldValue.Children {
+			paths = append(paths, valueDiffPaths(oldValue.Children[i].Value, newValue.Children[i].Value, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	default:
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// directiveArgumentDiff returns the difference between the values of the directive argument, field sets are diffed as normalized strings
+func directiveArgumentDiff(directive string, oldArgument *ast.Argument, newArgument *ast.Argument) *ValueDiff {
+	if fieldSetArguments[directive] == oldArgument.Name {
+		return &ValueDiff{
+			Old: normalizeArgumentValue(directive, oldArgument.Name, oldArgument.Value),
+			New: normalizeArgumentValue(directive, newArgument.Name, newArgument.Value),
+		}
+	}
+	return newValueDiff(oldArgument.Value, newArgument.Value)
+}
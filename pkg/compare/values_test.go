@@ -0,0 +1,141 @@
+package compare
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestCompareValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldSchema  string
+		newSchema  string
+		changeType ChangeType
+		diff       *ValueDiff
+	}{
+		{
+			name:      "Object default value with reordered fields",
+			oldSchema: `type Query { books(filter: Filter = {year: 2000, title: "Dune"}): [String] } input Filter { title: String year: Int }`,
+			newSchema: `type Query { books(filter: Filter = {title: "Dune", year: 2000}): [String] } input Filter { title: String year: Int }`,
+		},
+		{
+			name:      "Float default value written differently",
+			oldSchema: `type Query { books(rating: Float = 1.50): [String] }`,
+			newSchema: `type Query { books(rating: Float = 1.5): [String] }`,
+		},
+		{
+			name:       "Object default value field changed",
+			oldSchema:  `type Query { books(filter: Filter = {title: "Dune", range: {from: 1960, to: 1970}}): [String] } input Filter { title: String range: Range } input Range { from: Int to: Int }`,
+			newSchema:  `type Query { books(filter: Filter = {range: {to: 1980, from: 1960}, title: "Dune"}): [String] } input Filter { title: String range: Range } input Range { from: Int to: Int }`,
+			changeType: FieldArgumentDefaultChanged,
+			diff: &ValueDiff{
+				Old:   `{title:"Dune",range:{from:1960,to:1970}}`,
+				New:   `{range:{to:1980,from:1960},title:"Dune"}`,
+				Paths: []string{"range.to"},
+			},
+		},
+		{
+			name:       "List default value order changed",
+			oldSchema:  `input Filter { tags: [String] = ["a", "b"] }`,
+			newSchema:  `input Filter { tags: [String] = ["b", "a"] }`,
+			changeType: InputFieldDefaultValueChanged,
+			diff: &ValueDiff{
+				Old:   `["a","b"]`,
+				New:   `["b","a"]`,
+				Paths: []string{"[0]", "[1]"},
+			},
+		},
+		{
+			name:       "List default value item added",
+			oldSchema:  `directive @cache(tags: [String] = ["a"]) on FIELD_DEFINITION`,
+			newSchema:  `directive @cache(tags: [String] = ["a", "b"]) on FIELD_DEFINITION`,
+			changeType: DirectiveArgumentDefaultValueChanged,
+			diff: &ValueDiff{
+				Old: `["a"]`,
+				New: `["a","b"]`,
+			},
+		},
+		{
+			name:      "Directive argument object value with reordered fields",
+			oldSchema: `type Book { id: ID @cost(weights: {read: 1, write: 2}) }`,
+			newSchema: `type Book { id: ID @cost(weights: {write: 2, read: 1}) }`,
+		},
+		{
+			name:      "Key field set with different whitespace and order",
+			oldSchema: `type Book @key(fields: "id  author { name id }") { id: ID author: Author } type Author { id: ID name: String }`,
+			newSchema: `type Book @key(fields: "author { id name } id") { id: ID author: Author } type Author { id: ID name: String }`,
+		},
+		{
+			name:       "Key field set changed",
+			oldSchema:  `type Book @key(fields: "id") { id: ID isbn: String }`,
+			newSchema:  `type Book @key(fields: "id isbn") { id: ID isbn: String }`,
+			changeType: DirectiveArgumentValueChanged,
+			diff: &ValueDiff{
+				Old: `"id"`,
+				New: `"id isbn"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			oldSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.oldSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing old schema, error = %v", err)
+			}
+			newSchema, err := parser.ParseSchema(&ast.Source{
+				Input: tt.newSchema,
+			})
+			if err != nil {
+				t.Fatalf("error parsing new schema, error = %v", err)
+			}
+			changes := FindChangesInSchemas(oldSchema, newSchema)
+			if tt.diff == nil {
+				if len(changes) != 0 {
+					t.Fatalf("Unexpected changes added = %v", changes)
+				}
+				return
+			}
+			if len(changes) != 1 {
+				t.Fatalf("Unexpected changes added = %v", changes)
+			}
+			if changes[0].changeType != tt.changeType {
+				t.Errorf("changeType = %v, want %v", changes[0].changeType, tt.changeType)
+			}
+			if !reflect.DeepEqual(changes[0].GetValueDiff(), tt.diff) {
+				t.Errorf("GetValueDiff() = %+v, want %+v", changes[0].GetValueDiff(), tt.diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: `{b: 1, a: [{d: 2.0, c: "x"}]}`, want: `{a: [{c: "x", d: 2}], b: 1}`},
+		{value: `["b", "a"]`, want: `["b", "a"]`},
+		{value: `"""block"""`, want: `"block"`},
+		{value: `null`, want: `null`},
+		{value: `ENUM`, want: `ENUM`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.value, func(t *testing.T) {
+			schema, err := parser.ParseSchema(&ast.Source{Input: "input Value { value: String = " + tt.value + " }"})
+			if err != nil {
+				t.Fatalf("error parsing value, error = %v", err)
+			}
+			if got := normalizeValue(schema.Definitions[0].Fields[0].DefaultValue); got != tt.want {
+				t.Errorf("normalizeValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/CrowdStrike/gql/pkg/linter"
+	"github.com/CrowdStrike/gql/utils"
+)
+
+// FileNames are the names of the config files discovered walking up from the working directory, in order of preference
+var FileNames = []string{".gqlrc.yaml", ".gqlrc.yml", "gql.config.json"}
+
+// Config is the project configuration shared by lint and compare commands
+type Config struct {
+	Lint    LintConfig    `yaml:"lint" json:"lint"`
+	Compare CompareConfig `yaml:"compare" json:"compare"`
+
+	// dir is the directory of the config file, relative paths in the config are resolved against it
+	dir string
+}
+
+// LintConfig holds the settings for the lint command
+type LintConfig struct {
+	// Schema is the list of globs of the schema files to lint
+	Schema []string `yaml:"schema" json:"schema"`
+	// Rules is the list of enabled rules, all the available rules are enabled when it is empty
+	Rules []string `yaml:"rules" json:"rules"`
+	// Disabled is the list of rules which are never applied
+	Disabled []string `yaml:"disabled" json:"disabled"`
+	// Severity maps a rule name to its severity, one of: error, warn, off
+	Severity map[string]string `yaml:"severity" json:"severity"`
+	// Overrides change the rules for schema files matching their globs
+	Overrides []LintOverride `yaml:"overrides" json:"overrides"`
+	// CustomRules are declarative rules which are registered along with the stock rules
+	CustomRules []linter.DeclarativeRule `yaml:"customRules" json:"customRules"`
+	// MaxWarnings is the number of warnings which fails the lint, any number of warnings is allowed when it's nil
+	MaxWarnings *int `yaml:"maxWarnings" json:"maxWarnings"`
+}
+
+// LintOverride holds rule settings which only apply to the schema files matching Files
+type LintOverride struct {
+	// Files is the list of globs of schema files the override applies to
+	Files []string `yaml:"files" json:"files"`
+	// Rules is the list of rules additionally enabled for the matching files
+	Rules []string `yaml:"rules" json:"rules"`
+	// Disabled is the list of rules which are not applied to the matching files
+	Disabled []string `yaml:"disabled" json:"disabled"`
+	// Severity maps a rule name to its severity for the matching files
+	Severity map[string]string `yaml:"severity" json:"severity"`
+}
+
+// CompareConfig holds the settings for the compare command
+type CompareConfig struct {
+	// Baseline is the glob of the old version of the schema
+	Baseline string `yaml:"baseline" json:"baseline"`
+	// Schema is the glob of the new version of the schema
+	Schema string `yaml:"schema" json:"schema"`
+	// FailOn is the list of change criticalities which fail the build, only breaking changes fail it when empty
+	FailOn []string `yaml:"failOn" json:"failOn"`
+	// Operations is the list of globs of the client operations and persisted query manifests breaking changes are checked against
+	Operations []string `yaml:"operations" json:"operations"`
+	// Federation is the Apollo Federation version of the subgraph schemas, one of v1 or v2, federation rules aren't applied when it is empty
+	Federation string `yaml:"federation" json:"federation"`
+	// AcceptFile is the path of the file listing the accepted changes, which don't fail the compare
+	AcceptFile string `yaml:"acceptFile" json:"acceptFile"`
+	// LinkTemplate links the positions in the new schema of the markdown report e.g. https://github.com/org/repo/blob/main/{file}#L{line}
+	LinkTemplate string `yaml:"linkTemplate" json:"linkTemplate"`
+	// OldLinkTemplate links the positions in the old schema of the markdown report, LinkTemplate is used when it is empty
+	OldLinkTemplate string `yaml:"oldLinkTemplate" json:"oldLinkTemplate"`
+	// Overrides change the criticality of the changes matching them, the last matching override wins
+	Overrides []CriticalityOverride `yaml:"overrides" json:"overrides"`
+	// DeprecationPolicy checks removals against their deprecation, removals aren't checked when it is nil
+	DeprecationPolicy *DeprecationPolicyConfig `yaml:"deprecationPolicy" json:"deprecationPolicy"`
+}
+
+// CriticalityOverride sets the criticality of the changes of a type, optionally narrowed by path glob or directive name
+type CriticalityOverride struct {
+	// ChangeType is the type of the changes the override applies to e.g. ENUM_VALUE_ADDED
+	ChangeType string `yaml:"changeType" json:"changeType"`
+	// Path is the glob the change path has to match e.g. Status.*
+	Path string `yaml:"path" json:"path"`
+	// Directive is the name of the directive the change has to be about e.g. auth
+	Directive string `yaml:"directive" json:"directive"`
+	// Criticality is the criticality of the matching changes, one of: Breaking, Dangerous, NonBreaking
+	Criticality string `yaml:"criticality" json:"criticality"`
+}
+
+// DeprecationPolicyConfig holds the settings of the deprecation policy removals are checked against
+type DeprecationPolicyConfig struct {
+	// Directive is the directive holding the removal date, @deprecated when it is empty
+	Directive string `yaml:"directive" json:"directive"`
+	// Argument is the argument of the directive holding the removal date, removeAfter when it is empty
+	Argument string `yaml:"argument" json:"argument"`
+	// CurrentVersion is the version of the schema removal versions in deprecation reasons are compared with
+	CurrentVersion string `yaml:"currentVersion" json:"currentVersion"`
+	// MinimumWindowDays is the fewest days allowed between the deprecation date and the removal date, not checked when it is 0
+	MinimumWindowDays int `yaml:"minimumWindowDays" json:"minimumWindowDays"`
+}
+
+// LintSettings is the resolved list of rules and severities for a single schema file
+type LintSettings struct {
+	Rules    []string
+	Disabled []string
+	Severity map[string]string
+}
+
+// Load reads the config file from the given path; .json files are decoded as JSON and everything else as YAML
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file:%s, error:%v", path, err)
+	}
+	cfg := &Config{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(cfg)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		err = decoder.Decode(cfg)
+		if errors.Is(err, io.EOF) { // empty config file
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file:%s, error:%v", path, err)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config file:%s, error:%v", path, err)
+	}
+	cfg.dir = filepath.Dir(absPath)
+	return cfg, nil
+}
+
+// Find walks up from the given directory and returns the path of the first config file found, or empty string if there's none
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		for _, name := range FileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Resolve loads the config file from the given path, or discovers it from the working directory when path is empty.
+// An empty config is returned when no config file is found.
+func Resolve(path string) (*Config, error) {
+	if len(path) == 0 {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		path, err = Find(wd)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return &Config{}, nil
+		}
+	}
+	return Load(path)
+}
+
+// ResolvePath resolves a path or glob from the config file against the config file directory.
+// The result is relative to the working directory when possible so that reported file names stay short.
+// Endpoint URLs, e.g. a compare baseline of https://host/graphql, are returned unchanged.
+func (c *Config) ResolvePath(path string) string {
+	if len(c.dir) == 0 || filepath.IsAbs(path) || utils.IsEndpoint(path) {
+		return path
+	}
+	resolved := filepath.Join(c.dir, path)
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, resolved); err == nil {
+			return rel
+		}
+	}
+	return resolved
+}
+
+// LintSettingsForFile returns the rule settings for the given schema file with all the matching overrides applied in order
+func (c *Config) LintSettingsForFile(filename string) LintSettings {
+	settings := LintSettings{
+		Rules:    append([]string{}, c.Lint.Rules...),
+		Disabled: append([]string{}, c.Lint.Disabled...),
+		Severity: make(map[string]string),
+	}
+	for rule, severity := range c.Lint.Severity {
+		settings.Severity[rule] = severity
+	}
+	for _, override := range c.Lint.Overrides {
+		if !c.matchesAny(override.Files, filename) {
+			continue
+		}
+		if len(settings.Rules) != 0 {
+			// with an empty list all the rules are enabled already
+			settings.Rules = append(settings.Rules, override.Rules...)
+		}
+		settings.Disabled = without(settings.Disabled, override.Rules)
+		settings.Disabled = append(settings.Disabled, override.Disabled...)
+		for rule, severity := range override.Severity {
+			settings.Severity[rule] = severity
+		}
+	}
+	return settings
+}
+
+func (c *Config) matchesAny(globs []string, filename string) bool {
+	absFilename, err := filepath.Abs(filename)
+	if err != nil {
+		return false
+	}
+	for _, glob := range globs {
+		// globs without a directory match the file name in any directory
+		if !strings.ContainsRune(glob, '/') {
+			if matched, _ := filepath.Match(glob, filepath.Base(filename)); matched {
+				return true
+			}
+			continue
+		}
+		absGlob, err := filepath.Abs(c.ResolvePath(filepath.FromSlash(glob)))
+		if err != nil {
+			continue
+		}
+		if matched, _ := filepath.Match(absGlob, absFilename); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func without(rules []string, removed []string) []string {
+	remaining := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		found := false
+		for _, r := range removed {
+			if strings.EqualFold(rule, r) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			remaining = append(remaining, rule)
+		}
+	}
+	return remaining
+}
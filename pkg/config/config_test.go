@@ -0,0 +1,256 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/CrowdStrike/gql/pkg/linter"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create directory, error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write file, error = %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		want     Config
+		wantErr  bool
+	}{
+		{
+			name:     "yaml config",
+			fileName: ".gqlrc.yaml",
+			content: `
+lint:
+  schema: ["schema/*.graphql"]
+  disabled: [type-desc]
+  severity:
+    field-desc: "off"
+compare:
+  baseline: old/*.graphql
+  failOn: [Breaking, Dangerous]
+`,
+			want: Config{
+				Lint: LintConfig{
+					Schema:   []string{"schema/*.graphql"},
+					Disabled: []string{"type-desc"},
+					Severity: map[string]string{"field-desc": "off"},
+				},
+				Compare: CompareConfig{
+					Baseline: "old/*.graphql",
+					FailOn:   []string{"Breaking", "Dangerous"},
+				},
+			},
+		},
+		{
+			name:     "json config",
+			fileName: "gql.config.json",
+			content:  `{"lint": {"rules": ["type-caps"]}, "compare": {"schema": "new/*.graphql"}}`,
+			want: Config{
+				Lint:    LintConfig{Rules: []string{"type-caps"}},
+				Compare: CompareConfig{Schema: "new/*.graphql"},
+			},
+		},
+		{
+			name:     "custom rules",
+			fileName: ".gqlrc.yaml",
+			content: `
+lint:
+  customRules:
+    - name: id-arg
+      severity: warn
+      select: {target: argument, name: id}
+      assert: {type: "ID!"}
+`,
+			want: Config{
+				Lint: LintConfig{
+					CustomRules: []linter.DeclarativeRule{
+						{
+							Name:      "id-arg",
+							Severity:  "warn",
+							Selector:  linter.Selector{Target: linter.TargetArgument, Name: "id"},
+							Assertion: linter.Assertion{Type: "ID!"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "deprecation policy",
+			fileName: ".gqlrc.yaml",
+			content: `
+compare:
+  deprecationPolicy:
+    argument: removeAfter
+    minimumWindowDays: 90
+`,
+			want: Config{
+				Compare: CompareConfig{
+					DeprecationPolicy: &DeprecationPolicyConfig{Argument: "removeAfter", MinimumWindowDays: 90},
+				},
+			},
+		},
+		{
+			name:     "empty yaml config",
+			fileName: ".gqlrc.yaml",
+			content:  ``,
+			want:     Config{},
+		},
+		{
+			name:     "unknown field",
+			fileName: ".gqlrc.yaml",
+			content:  `lint: {rulez: [type-caps]}`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, tt.fileName)
+			writeFile(t, path, tt.content)
+			got, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			tt.want.dir = dir
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Load() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".gqlrc.yaml")
+	writeFile(t, path, `
+compare:
+  baseline: https://library.example.com/graphql
+  schema: schema/*.graphql
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory, error = %v", err)
+	}
+	schema, err := filepath.Rel(wd, filepath.Join(dir, "schema", "*.graphql"))
+	if err != nil {
+		t.Fatalf("failed to get relative path, error = %v", err)
+	}
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "endpoint baseline", path: cfg.Compare.Baseline, want: "https://library.example.com/graphql"},
+		{name: "schema glob", path: cfg.Compare.Schema, want: schema},
+		{name: "absolute path", path: filepath.Join(wd, "schema.graphql"), want: filepath.Join(wd, "schema.graphql")},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.ResolvePath(tt.path); got != tt.want {
+				t.Errorf("ResolvePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "services", "library")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("failed to create directory, error = %v", err)
+	}
+
+	path, err := Find(nested)
+	if err != nil || len(path) != 0 {
+		t.Errorf("Find() = %s, %v; want no config file", path, err)
+	}
+
+	writeFile(t, filepath.Join(dir, "gql.config.json"), `{}`)
+	writeFile(t, filepath.Join(dir, "services", ".gqlrc.yaml"), ``)
+	path, err = Find(nested)
+	if err != nil || path != filepath.Join(dir, "services", ".gqlrc.yaml") {
+		t.Errorf("Find() = %s, %v; want the closest config file", path, err)
+	}
+}
+
+func TestLintSettingsForFile(t *testing.T) {
+	cfg := &Config{
+		Lint: LintConfig{
+			Disabled: []string{"type-desc"},
+			Severity: map[string]string{"field-desc": "warn"},
+			Overrides: []LintOverride{
+				{
+					Files:    []string{"legacy/*.graphql"},
+					Rules:    []string{"type-desc"},
+					Disabled: []string{"field-camel"},
+				},
+				{
+					Files:    []string{"*.generated.graphql"},
+					Severity: map[string]string{"field-desc": "off"},
+				},
+			},
+		},
+		dir: t.TempDir(),
+	}
+	tests := []struct {
+		name     string
+		filename string
+		want     LintSettings
+	}{
+		{
+			name:     "no matching override",
+			filename: filepath.Join(cfg.dir, "schema.graphql"),
+			want: LintSettings{
+				Rules:    []string{},
+				Disabled: []string{"type-desc"},
+				Severity: map[string]string{"field-desc": "warn"},
+			},
+		},
+		{
+			name:     "override matching path",
+			filename: filepath.Join(cfg.dir, "legacy", "schema.graphql"),
+			want: LintSettings{
+				Rules:    []string{},
+				Disabled: []string{"field-camel"},
+				Severity: map[string]string{"field-desc": "warn"},
+			},
+		},
+		{
+			name:     "override matching file name",
+			filename: filepath.Join(cfg.dir, "api", "schema.generated.graphql"),
+			want: LintSettings{
+				Rules:    []string{},
+				Disabled: []string{"type-desc"},
+				Severity: map[string]string{"field-desc": "off"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.LintSettingsForFile(tt.filename); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintSettingsForFile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package linter
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	// TargetType selects type definitions
+	TargetType = "type"
+	// TargetField selects fields of objects and interfaces and input fields
+	TargetField = "field"
+	// TargetArgument selects field arguments
+	TargetArgument = "argument"
+	// TargetEnumValue selects enum values
+	TargetEnumValue = "enumValue"
+)
+
+// DeclarativeRule is a lint rule declared in the config file instead of Go code.
+// It reports every schema element matched by the selector which fails the assertion.
+type DeclarativeRule struct {
+	Name        string    `yaml:"name" json:"name"`
+	Description string    `yaml:"description" json:"description"`
+	Severity    string    `yaml:"severity" json:"severity"`
+	Selector    Selector  `yaml:"select" json:"select"`
+	Assertion   Assertion `yaml:"assert" json:"assert"`
+}
+
+// Selector selects the schema elements a declarative rule applies to, every non-empty criteria has to match
+type Selector struct {
+	// Target is the kind of schema element selected, one of: type, field, argument, enumValue; defaults to type
+	Target string `yaml:"target" json:"target"`
+	// Kinds restricts the selection to types, or members of types, of the given kinds e.g. OBJECT, INPUT_OBJECT
+	Kinds []string `yaml:"kinds" json:"kinds"`
+	// Type is a glob matching the name of the type, or of the type the member belongs to
+	Type string `yaml:"type" json:"type"`
+	// Name is a glob matching the name of the selected field, argument or enum value
+	Name string `yaml:"name" json:"name"`
+	// Directive is the name of a directive the selected element has
+	Directive string `yaml:"directive" json:"directive"`
+	// FieldType is a glob matching the named type of the selected field or argument
+	FieldType string `yaml:"fieldType" json:"fieldType"`
+}
+
+// Assertion is what every selected schema element has to satisfy
+type Assertion struct {
+	// NamePattern is a regular expression the name of the element has to match
+	NamePattern string `yaml:"namePattern" json:"namePattern"`
+	// RequireDescription requires the element to have a description
+	RequireDescription bool `yaml:"requireDescription" json:"requireDescription"`
+	// Nullable requires the field or argument type to be nullable when true and non-null when false
+	Nullable *bool `yaml:"nullable" json:"nullable"`
+	// Type is the exact type the field or argument has to be e.g. ID!
+	Type string `yaml:"type" json:"type"`
+	// AllowedDirectives is the list of the only directives the element can have
+	AllowedDirectives []string `yaml:"allowedDirectives" json:"allowedDirectives"`
+}
+
+// selectedElement is a schema element matched by a selector
+type selectedElement struct {
+	kind        string
+	path        string
+	name        string
+	description string
+	typ         *ast.Type
+	directives  ast.DirectiveList
+	position    *ast.Position
+	isExtension bool
+}
+
+// Compile validates the declarative rule and turns it into a rule which can be registered with RegisterRule
+func (r DeclarativeRule) Compile() (LintRuleMetadata, error) {
+	rule := LintRuleMetadata{
+		Name:        LintRule(r.Name),
+		Description: r.Description,
+		Severity:    SeverityError,
+	}
+	if len(rule.Description) == 0 {
+		rule.Description = fmt.Sprintf("%s is a declarative rule from the config file", r.Name)
+	}
+	if len(r.Severity) != 0 {
+		severity, err := ParseSeverity(r.Severity)
+		if err != nil {
+			return rule, fmt.Errorf("rule[%s]: %v", r.Name, err)
+		}
+		rule.Severity = severity
+	}
+
+	selector := r.Selector
+	if len(selector.Target) == 0 {
+		selector.Target = TargetType
+	}
+	switch selector.Target {
+	case TargetType, TargetField, TargetArgument, TargetEnumValue:
+	default:
+		return rule, fmt.Errorf("rule[%s]: invalid target[%s], expected one of: type, field, argument, enumValue", r.Name, selector.Target)
+	}
+	for _, kind := range selector.Kinds {
+		switch ast.DefinitionKind(strings.ToUpper(kind)) {
+		case ast.Scalar, ast.Object, ast.Interface, ast.Union, ast.Enum, ast.InputObject:
+		default:
+			return rule, fmt.Errorf("rule[%s]: invalid kind[%s]", r.Name, kind)
+		}
+	}
+	for _, glob := range []string{selector.Type, selector.Name, selector.FieldType} {
+		if _, err := path.Match(glob, ""); err != nil {
+			return rule, fmt.Errorf("rule[%s]: invalid glob[%s], error:%v", r.Name, glob, err)
+		}
+	}
+
+	assertion := r.Assertion
+	var namePattern *regexp.Regexp
+	if len(assertion.NamePattern) != 0 {
+		var err error
+		if namePattern, err = regexp.Compile(assertion.NamePattern); err != nil {
+			return rule, fmt.Errorf("rule[%s]: invalid namePattern[%s], error:%v", r.Name, assertion.NamePattern, err)
+		}
+	}
+	if (assertion.Nullable != nil || len(assertion.Type) != 0) && selector.Target != TargetField && selector.Target != TargetArgument {
+		return rule, fmt.Errorf("rule[%s]: nullable and type assertions apply to fields and arguments only", r.Name)
+	}
+
+	rule.RuleFunction = func(schema *ast.SchemaDocument) LintErrorsWithMetadata {
+		errors := make([]LintErrorWithMetadata, 0)
+		for _, element := range selectElements(schema, selector) {
+			for _, err := range assertElement(element, assertion, namePattern) {
+				errors = append(errors, LintErrorWithMetadata{
+					Rule:   rule.Name,
+					Line:   element.position.Line,
+					Column: element.position.Column,
+					Err:    err,
+				})
+			}
+		}
+		return errors
+	}
+	return rule, nil
+}
+
+// selectElements returns all the schema elements matching the selector, extended types included
+func selectElements(schema *ast.SchemaDocument, selector Selector) []selectedElement {
+	elements := make([]selectedElement, 0)
+	selectFrom := func(definitions ast.DefinitionList, isExtension bool) {
+		for _, definition := range definitions {
+			if !matchesKind(selector.Kinds, definition.Kind) || !matchesGlob(selector.Type, definition.Name) {
+				continue
+			}
+			switch selector.Target {
+			case TargetType:
+				elements = append(elements, selectedElement{
+					kind:        "type",
+					path:        definition.Name,
+					name:        definition.Name,
+					description: definition.Description,
+					directives:  definition.Directives,
+					position:    definition.Position,
+					isExtension: isExtension,
+				})
+			case TargetField:
+				kind := "field"
+				if definition.Kind == ast.InputObject {
+					kind = "input field"
+				}
+				for _, field := range definition.Fields {
+					elements = append(elements, selectedElement{
+						kind:        kind,
+						path:        fmt.Sprintf("%s.%s", definition.Name, field.Name),
+						name:        field.Name,
+						description: field.Description,
+						typ:         field.Type,
+						directives:  field.Directives,
+						position:    field.Type.Position,
+					})
+				}
+			case TargetArgument:
+				for _, field := range definition.Fields {
+					for _, argument := range field.Arguments {
+						elements = append(elements, selectedElement{
+							kind:        "argument",
+							path:        fmt.Sprintf("%s.%s.%s", definition.Name, field.Name, argument.Name),
+							name:        argument.Name,
+							description: argument.Description,
+							typ:         argument.Type,
+							directives:  argument.Directives,
+							position:    argument.Position,
+						})
+					}
+				}
+			case TargetEnumValue:
+				for _, enumValue := range definition.EnumValues {
+					elements = append(elements, selectedElement{
+						kind:        "enum value",
+						path:        fmt.Sprintf("%s.%s", definition.Name, enumValue.Name),
+						name:        enumValue.Name,
+						description: enumValue.Description,
+						directives:  enumValue.Directives,
+						position:    enumValue.Position,
+					})
+				}
+			}
+		}
+	}
+	selectFrom(schema.Definitions, false)
+	// extended types are not included in schema.definitions but schema.extensions
+	selectFrom(schema.Extensions, true)
+
+	selected := make([]selectedElement, 0, len(elements))
+	for _, element := range elements {
+		if selector.Target != TargetType && !matchesGlob(selector.Name, element.name) {
+			continue
+		}
+		if len(selector.Directive) != 0 && element.directives.ForName(strings.TrimPrefix(selector.Directive, "@")) == nil {
+			continue
+		}
+		if len(selector.FieldType) != 0 && (element.typ == nil || !matchesGlob(selector.FieldType, element.typ.Name())) {
+			continue
+		}
+		selected = append(selected, element)
+	}
+	return selected
+}
+
+func assertElement(element selectedElement, assertion Assertion, namePattern *regexp.Regexp) []error {
+	errors := make([]error, 0)
+	if namePattern != nil && !namePattern.MatchString(element.name) {
+		errors = append(errors, fmt.Errorf("%s %s does not match pattern %s", element.kind, element.path, namePattern))
+	}
+	// extended types should not have descriptions since that can collide with type being extended
+	if assertion.RequireDescription && !element.isExtension && len(element.description) == 0 {
+		errors = append(errors, fmt.Errorf("%s %s does not have description", element.kind, element.path))
+	}
+	if assertion.Nullable != nil && element.typ != nil && element.typ.NonNull == *assertion.Nullable {
+		if *assertion.Nullable {
+			errors = append(errors, fmt.Errorf("%s %s should be nullable", element.kind, element.path))
+		} else {
+			errors = append(errors, fmt.Errorf("%s %s should be non-null", element.kind, element.path))
+		}
+	}
+	if len(assertion.Type) != 0 && element.typ != nil && element.typ.String() != assertion.Type {
+		errors = append(errors, fmt.Errorf("%s %s should be of type %s instead of %s", element.kind, element.path, assertion.Type, element.typ.String()))
+	}
+	if assertion.AllowedDirectives != nil {
+		for _, directive := range element.directives {
+			if !matchesAnyName(assertion.AllowedDirectives, directive.Name) {
+				errors = append(errors, fmt.Errorf("%s %s has directive @%s which is not allowed", element.kind, element.path, directive.Name))
+			}
+		}
+	}
+	return errors
+}
+
+func matchesKind(kinds []string, kind ast.DefinitionKind) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for _, k := range kinds {
+		if strings.EqualFold(k, string(kind)) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesGlob(glob string, name string) bool {
+	if len(glob) == 0 {
+		return true
+	}
+	matched, _ := path.Match(glob, name)
+	return matched
+}
+
+func matchesAnyName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.TrimPrefix(n, "@") == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package linter
+
+import (
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestDeclarativeRule(t *testing.T) {
+	nullable := true
+	tests := []struct {
+		name       string
+		rule       DeclarativeRule
+		schema     string
+		wantErrors int
+	}{
+		{
+			"input_fields_are_nullable",
+			DeclarativeRule{
+				Name:      "input-nullable",
+				Selector:  Selector{Target: TargetField, Type: "*Input"},
+				Assertion: Assertion{Nullable: &nullable},
+			},
+			`
+			input BookInput { isbn: String!, title: String }
+			input AuthorFilter { name: String! }
+			type Book { isbn: String! }
+			`,
+			1,
+		},
+		{
+			"id_arguments_are_non_null_ids",
+			DeclarativeRule{
+				Name:      "id-arg",
+				Selector:  Selector{Target: TargetArgument, Name: "id"},
+				Assertion: Assertion{Type: "ID!"},
+			},
+			`
+			type Query {
+				book(id: ID!): String
+				author(id: String): String
+				library(libraryId: String): String
+			}
+			extend type Query { shelf(id: ID): String }
+			`,
+			2,
+		},
+		{
+			"mutation_inputs_end_with_input",
+			DeclarativeRule{
+				Name:      "input-suffix",
+				Selector:  Selector{Kinds: []string{"input_object"}},
+				Assertion: Assertion{NamePattern: "Input$"},
+			},
+			`
+			input BookInput { isbn: String }
+			input AuthorFilter { name: String }
+			enum Color { RED }
+			`,
+			1,
+		},
+		{
+			"id_fields_are_named_id",
+			DeclarativeRule{
+				Name:      "id-name",
+				Selector:  Selector{Target: TargetField, FieldType: "ID"},
+				Assertion: Assertion{NamePattern: "(^id|Id)$"},
+			},
+			`
+			type Book { id: ID!, isbn: ID, similar: [ID!] }
+			type Author { authorId: ID, name: String }
+			`,
+			2,
+		},
+		{
+			"fields_with_directive",
+			DeclarativeRule{
+				Name:      "external-desc",
+				Selector:  Selector{Target: TargetField, Directive: "external"},
+				Assertion: Assertion{RequireDescription: true},
+			},
+			`
+			type Book {
+				isbn: ID @external
+				"described"
+				title: String @external
+				year: Int
+			}
+			`,
+			1,
+		},
+		{
+			"extended_types_skip_description",
+			DeclarativeRule{
+				Name:      "type-desc-decl",
+				Assertion: Assertion{RequireDescription: true},
+			},
+			`
+			type Book { isbn: ID }
+			extend type Query { book: Book }
+			`,
+			1,
+		},
+		{
+			"allowed_directives_on_enum_values",
+			DeclarativeRule{
+				Name:      "enum-directives",
+				Selector:  Selector{Target: TargetEnumValue, Type: "Color"},
+				Assertion: Assertion{AllowedDirectives: []string{"deprecated"}},
+			},
+			`
+			enum Color { RED @deprecated, GREEN @internal, BLUE }
+			enum Size { SMALL @internal }
+			`,
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaDoc, parseErr := parser.ParseSchema(&ast.Source{
+				Input: tt.schema,
+			})
+			if parseErr != nil {
+				t.Fatalf("DeclarativeRule() invalid input; error = %v", parseErr)
+			}
+			rule, err := tt.rule.Compile()
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			errs := rule.RuleFunction(schemaDoc)
+			if errs.Len() != tt.wantErrors {
+				t.Errorf("DeclarativeRule() errors = %v, want %d errors", errs, tt.wantErrors)
+			}
+			for _, lintErr := range errs {
+				if lintErr.Rule != LintRule(tt.rule.Name) {
+					t.Errorf("DeclarativeRule() error rule = %s, want %s", lintErr.Rule, tt.rule.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestDeclarativeRuleCompile(t *testing.T) {
+	nullable := false
+	tests := []struct {
+		name    string
+		rule    DeclarativeRule
+		wantErr bool
+	}{
+		{
+			"valid_rule",
+			DeclarativeRule{Name: "id-arg", Severity: "warn", Selector: Selector{Target: TargetArgument}, Assertion: Assertion{Nullable: &nullable}},
+			false,
+		},
+		{
+			"invalid_target",
+			DeclarativeRule{Name: "bad-target", Selector: Selector{Target: "directive"}},
+			true,
+		},
+		{
+			"invalid_kind",
+			DeclarativeRule{Name: "bad-kind", Selector: Selector{Kinds: []string{"CLASS"}}},
+			true,
+		},
+		{
+			"invalid_glob",
+			DeclarativeRule{Name: "bad-glob", Selector: Selector{Type: "[Input"}},
+			true,
+		},
+		{
+			"invalid_name_pattern",
+			DeclarativeRule{Name: "bad-pattern", Assertion: Assertion{NamePattern: "(Input"}},
+			true,
+		},
+		{
+			"invalid_severity",
+			DeclarativeRule{Name: "bad-severity", Severity: "fatal"},
+			true,
+		},
+		{
+			"nullable_on_types",
+			DeclarativeRule{Name: "bad-nullable", Assertion: Assertion{Nullable: &nullable}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.rule.Compile(); (err != nil) != tt.wantErr {
+				t.Errorf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
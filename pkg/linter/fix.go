@@ -0,0 +1,281 @@
+package linter
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// LintFixFunc is a short-form for the function signature of the function which finds fixes for lint errors of a Rule
+type LintFixFunc = func(schema *ast.SchemaDocument) []Fix
+
+// FixKind is the kind of mechanical change made to the schema by a fix
+type FixKind int
+
+const (
+	// RenameFix renames the element; when a type is renamed all the references to it are renamed as well
+	RenameFix FixKind = iota
+	// DescriptionFix adds a placeholder description to the element
+	DescriptionFix
+)
+
+// FixElement is the kind of schema element changed by a fix
+type FixElement int
+
+const (
+	// TypeElement is a type definition or extension
+	TypeElement FixElement = iota
+	// FieldElement is a field or an input field
+	FieldElement
+	// ArgumentElement is a field argument
+	ArgumentElement
+	// EnumValueElement is an enum value
+	EnumValueElement
+)
+
+// PlaceholderDescription is the description added by description fixes
+const PlaceholderDescription = "TODO: add description"
+
+// Fix is a mechanical change which resolves a lint error
+type Fix struct {
+	Rule    LintRule
+	Kind    FixKind
+	Element FixElement
+	// Parent is the name of the type the field, argument or enum value belongs to
+	Parent string
+	// Name is the current name of the element
+	Name string
+	// NewName is the name the element is renamed to by a RenameFix
+	NewName string
+	// Line is the line of the lint error resolved by the fix
+	Line int
+	// Position is the position of the element in the schema
+	Position *ast.Position
+}
+
+// TypesHaveDescriptionFixes adds placeholder descriptions to the types without description
+func TypesHaveDescriptionFixes(schema *ast.SchemaDocument) []Fix {
+	fixes := make([]Fix, 0)
+	for _, definition := range schema.Definitions {
+		if len(definition.Description) == 0 {
+			fixes = append(fixes, Fix{
+				Rule:     typeDesc,
+				Kind:     DescriptionFix,
+				Element:  TypeElement,
+				Name:     definition.Name,
+				Line:     definition.Position.Line,
+				Position: definition.Position,
+			})
+		}
+	}
+	return fixes
+}
+
+// ArgumentsHaveDescriptionFixes adds placeholder descriptions to the arguments without description
+func ArgumentsHaveDescriptionFixes(schema *ast.SchemaDocument) []Fix {
+	fixes := make([]Fix, 0)
+	for _, definitions := range []ast.DefinitionList{schema.Definitions, schema.Extensions} {
+		for _, definition := range definitions {
+			if !definition.IsCompositeType() {
+				continue
+			}
+			for _, field := range definition.Fields {
+				for _, argument := range field.Arguments {
+					if len(argument.Description) == 0 {
+						fixes = append(fixes, Fix{
+							Rule:     argsDesc,
+							Kind:     DescriptionFix,
+							Element:  ArgumentElement,
+							Parent:   definition.Name,
+							Name:     argument.Name,
+							Line:     argument.Position.Line,
+							Position: argument.Position,
+						})
+					}
+				}
+			}
+		}
+	}
+	return fixes
+}
+
+// FieldsHaveDescriptionFixes adds placeholder descriptions to the fields without description
+func FieldsHaveDescriptionFixes(schema *ast.SchemaDocument) []Fix {
+	fixes := make([]Fix, 0)
+	for _, definitions := range []ast.DefinitionList{schema.Definitions, schema.Extensions} {
+		for _, definition := range definitions {
+			for _, field := range definition.Fields {
+				if len(field.Description) == 0 {
+					fixes = append(fixes, Fix{
+						Rule:     fieldDesc,
+						Kind:     DescriptionFix,
+						Element:  FieldElement,
+						Parent:   definition.Name,
+						Name:     field.Name,
+						Line:     field.Type.Position.Line,
+						Position: field.Position,
+					})
+				}
+			}
+		}
+	}
+	return fixes
+}
+
+// EnumValuesAreAllCapsFixes renames the enum values to UPPER_CASE
+func EnumValuesAreAllCapsFixes(schema *ast.SchemaDocument) []Fix {
+	fixes := make([]Fix, 0)
+	for _, definitions := range []ast.DefinitionList{schema.Definitions, schema.Extensions} {
+		for _, definition := range definitions {
+			if definition.Kind != ast.Enum {
+				continue
+			}
+			for _, enumValue := range definition.EnumValues {
+				newName := ToUpperCase(enumValue.Name)
+				if newName == enumValue.Name || definition.EnumValues.ForName(newName) != nil {
+					continue
+				}
+				fixes = append(fixes, Fix{
+					Rule:     enumCaps,
+					Kind:     RenameFix,
+					Element:  EnumValueElement,
+					Parent:   definition.Name,
+					Name:     enumValue.Name,
+					NewName:  newName,
+					Line:     enumValue.Position.Line,
+					Position: enumValue.Position,
+				})
+			}
+		}
+	}
+	return fixes
+}
+
+// EnumValuesHaveDescriptionsFixes adds placeholder descriptions to the enum values without description
+func EnumValuesHaveDescriptionsFixes(schema *ast.SchemaDocument) []Fix {
+	fixes := make([]Fix, 0)
+	for _, definitions := range []ast.DefinitionList{schema.Definitions, schema.Extensions} {
+		for _, definition := range definitions {
+			if definition.Kind != ast.Enum {
+				continue
+			}
+			for _, enumValue := range definition.EnumValues {
+				if len(enumValue.Description) == 0 {
+					fixes = append(fixes, Fix{
+						Rule:     enumDesc,
+						Kind:     DescriptionFix,
+						Element:  EnumValueElement,
+						Parent:   definition.Name,
+						Name:     enumValue.Name,
+						Line:     enumValue.Position.Line,
+						Position: enumValue.Position,
+					})
+				}
+			}
+		}
+	}
+	return fixes
+}
+
+// FieldsAreCamelCasedFixes renames the fields to camelCase
+func FieldsAreCamelCasedFixes(schema *ast.SchemaDocument) []Fix {
+	fixes := make([]Fix, 0)
+	for _, definitions := range []ast.DefinitionList{schema.Definitions, schema.Extensions} {
+		for _, definition := range definitions {
+			for _, field := range definition.Fields {
+				if camelCaseRegex.MatchString(field.Name) {
+					continue
+				}
+				newName := ToCamelCase(field.Name)
+				if !camelCaseRegex.MatchString(newName) || definition.Fields.ForName(newName) != nil {
+					continue
+				}
+				fixes = append(fixes, Fix{
+					Rule:     fieldCamel,
+					Kind:     RenameFix,
+					Element:  FieldElement,
+					Parent:   definition.Name,
+					Name:     field.Name,
+					NewName:  newName,
+					Line:     field.Type.Position.Line,
+					Position: field.Position,
+				})
+			}
+		}
+	}
+	return fixes
+}
+
+// TypesAreCapitalizedFixes capitalizes the type names, references to the types are renamed when the fix is applied
+func TypesAreCapitalizedFixes(schema *ast.SchemaDocument) []Fix {
+	fixes := make([]Fix, 0)
+	for _, definitions := range []ast.DefinitionList{schema.Definitions, schema.Extensions} {
+		for _, definition := range definitions {
+			newName := capitalize(definition.Name)
+			if newName == definition.Name || schema.Definitions.ForName(newName) != nil {
+				continue
+			}
+			fixes = append(fixes, Fix{
+				Rule:     typeCaps,
+				Kind:     RenameFix,
+				Element:  TypeElement,
+				Name:     definition.Name,
+				NewName:  newName,
+				Line:     definition.Position.Line,
+				Position: definition.Position,
+			})
+		}
+	}
+	return fixes
+}
+
+// ToUpperCase converts a name to UPPER_CASE, word boundaries of camelCase names are separated with underscores
+func ToUpperCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// ToCamelCase converts a name to camelCase, underscores are treated as word boundaries
+func ToCamelCase(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if len(word) == 0 {
+			continue
+		}
+		if strings.ToUpper(word) == word {
+			word = strings.ToLower(word)
+		}
+		if b.Len() == 0 {
+			b.WriteString(lowerFirst(word))
+		} else {
+			b.WriteString(capitalize(word))
+		}
+	}
+	return b.String()
+}
+
+func capitalize(name string) string {
+	runes := []rune(name)
+	if len(runes) == 0 {
+		return name
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	if len(runes) == 0 {
+		return name
+	}
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,115 @@
+package linter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestToUpperCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"inProgress", "IN_PROGRESS"},
+		{"done", "DONE"},
+		{"ALREADY_DONE", "ALREADY_DONE"},
+		{"http2Request", "HTTP2_REQUEST"},
+		{"some_value", "SOME_VALUE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToUpperCase(tt.name); got != tt.want {
+				t.Errorf("ToUpperCase() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToCamelCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"book_title", "bookTitle"},
+		{"ALL_BOOKS", "allBooks"},
+		{"BookTitle", "bookTitle"},
+		{"_private_field", "privateField"},
+		{"book_ISBN", "bookIsbn"},
+		{"bookTitle", "bookTitle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCamelCase(tt.name); got != tt.want {
+				t.Errorf("ToCamelCase() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixFunctions(t *testing.T) {
+	schema := `
+	type book { ALL_BOOKS: String, book_title: String, bookTitle: String }
+	extend type book { extra_field: String }
+	"described" type Book { isbn: ID }
+	enum status { inProgress, DONE, done }
+	extend enum status { onHold }
+	`
+	tests := []struct {
+		name        string
+		fixFunction LintFixFunc
+		want        []string
+	}{
+		{
+			"enum_values_are_upper_cased",
+			EnumValuesAreAllCapsFixes,
+			// done is not renamed as DONE already exists
+			[]string{"status.inProgress=>IN_PROGRESS", "status.onHold=>ON_HOLD"},
+		},
+		{
+			"fields_are_camel_cased",
+			FieldsAreCamelCasedFixes,
+			// book_title is not renamed as bookTitle already exists
+			[]string{"book.ALL_BOOKS=>allBooks", "book.extra_field=>extraField"},
+		},
+		{
+			"types_are_capitalized",
+			TypesAreCapitalizedFixes,
+			// book is not renamed as Book already exists
+			[]string{".status=>Status", ".status=>Status"},
+		},
+		{
+			"types_have_description",
+			TypesHaveDescriptionFixes,
+			[]string{".book", ".status"},
+		},
+		{
+			"enum_values_have_description",
+			EnumValuesHaveDescriptionsFixes,
+			[]string{"status.inProgress", "status.DONE", "status.done", "status.onHold"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaDoc, parseErr := parser.ParseSchema(&ast.Source{
+				Input: schema,
+			})
+			if parseErr != nil {
+				t.Fatalf("FixFunction() invalid input; error = %v", parseErr)
+			}
+			got := make([]string, 0)
+			for _, fix := range tt.fixFunction(schemaDoc) {
+				if fix.Kind == RenameFix {
+					got = append(got, fix.Parent+"."+fix.Name+"=>"+fix.NewName)
+				} else {
+					got = append(got, fix.Parent+"."+fix.Name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FixFunction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
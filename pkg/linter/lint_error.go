@@ -9,6 +9,7 @@ type LintErrorWithMetadata struct {
 	Rule         LintRule
 	Line, Column int
 	Err          error
+	Severity     Severity
 }
 
 // LintErrorsWithMetadata represent collection of lint errors.
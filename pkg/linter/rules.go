@@ -19,15 +19,19 @@ type LintRule string
 // LintRuleMetadata holds information for a given lint Rule
 type LintRuleMetadata struct {
 	Name         LintRule
-	description  string
+	Description  string
 	RuleFunction LintRuleFunc
+	// Severity is the default severity of the rule
+	Severity Severity
+	// FixFunction finds the fixes for the lint errors of the rule, nil if they can't be fixed mechanically
+	FixFunction LintFixFunc
 }
 
 // AvailableRulesWithDescription returns the comma separated list of rules with description
 func AvailableRulesWithDescription() string {
 	availableRulesWithDescription := make([]string, 0)
 	for _, rule := range AllTheRules {
-		ruleWithDescription := fmt.Sprintf("	%s => %s", rule.Name, rule.description)
+		ruleWithDescription := fmt.Sprintf("	%s => %s", rule.Name, rule.Description)
 		availableRulesWithDescription = append(availableRulesWithDescription, ruleWithDescription)
 	}
 	return strings.Join(availableRulesWithDescription, "\n")
@@ -45,55 +49,97 @@ const (
 	relayConnArgs = "relay-conn-args"
 )
 
-// AllTheRules is a list of all the lint rules available
+// AllTheRules is a list of all the lint rules available, custom rules are added to it with RegisterRule
 var AllTheRules = []LintRuleMetadata{
 	{
 		typeDesc,
 		"type-desc checks whether all the types defined have description",
 		TypesHaveDescription,
+		SeverityError,
+		TypesHaveDescriptionFixes,
 	},
 	{
 		argsDesc,
 		"args-desc checks whether arguments have description",
 		ArgumentsHaveDescription,
+		SeverityError,
+		ArgumentsHaveDescriptionFixes,
 	},
 	{
 		fieldDesc,
 		"field-desc checks whether fields have description",
 		FieldsHaveDescription,
+		SeverityError,
+		FieldsHaveDescriptionFixes,
 	},
 	{
 		enumCaps,
 		"enum-caps checks whether Enum values are all UPPER_CASE",
 		EnumValuesAreAllCaps,
+		SeverityError,
+		EnumValuesAreAllCapsFixes,
 	},
 	{
 		enumDesc,
 		"enum-desc checks whether Enum values have description",
 		EnumValuesHaveDescriptions,
+		SeverityError,
+		EnumValuesHaveDescriptionsFixes,
 	},
 	{
 		fieldCamel,
 		"field-camel checks whether fields defined are all camelCase",
 		FieldsAreCamelCased,
+		SeverityError,
+		FieldsAreCamelCasedFixes,
 	},
 	{
 		typeCaps,
 		"type-caps checks whether types defined are Capitalized",
 		TypesAreCapitalized,
+		SeverityError,
+		TypesAreCapitalizedFixes,
 	},
 	{
 		relayConnType,
 		"relay-conn-type checks if Connection Types follow the Relay Cursor Connections Specification",
 		RelayConnectionTypesSpec,
+		SeverityError,
+		nil,
 	},
 	{
 		relayConnArgs,
 		"relay-conn-args checks if Connection Args follow of the Relay Cursor Connections Specification",
 		RelayConnectionArgumentsSpec,
+		SeverityError,
+		nil,
 	},
 }
 
+// RegisterRule adds a custom lint rule to AllTheRules so that it can be selected and configured like the stock rules.
+// It is not safe for concurrent use and should be called before the lint command runs, e.g. from an init function.
+func RegisterRule(rule LintRuleMetadata) error {
+	if len(strings.TrimSpace(string(rule.Name))) == 0 {
+		return fmt.Errorf("rule name can not be empty")
+	}
+	if strings.ContainsAny(string(rule.Name), ", :") {
+		return fmt.Errorf("rule name[%s] can not contain spaces, commas or colons", rule.Name)
+	}
+	if rule.RuleFunction == nil {
+		return fmt.Errorf("rule[%s] does not have a rule function", rule.Name)
+	}
+	for _, existingRule := range AllTheRules {
+		if strings.EqualFold(string(existingRule.Name), string(rule.Name)) {
+			return fmt.Errorf("rule[%s] is already registered", rule.Name)
+		}
+	}
+	if len(rule.Severity) == 0 {
+		rule.Severity = SeverityError
+	}
+	AllTheRules = append(AllTheRules, rule)
+	return nil
+}
+
 // TypesHaveDescription checks whether all the types defined have description
 func TypesHaveDescription(schema *ast.SchemaDocument) LintErrorsWithMetadata {
 	errors := make([]LintErrorWithMetadata, 0)
@@ -709,3 +709,63 @@ func TestRelayConnectionArgumentsSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterRule(t *testing.T) {
+	stockRules := AllTheRules
+	defer func() { AllTheRules = stockRules }()
+
+	inputSuffix := func(schema *ast.SchemaDocument) LintErrorsWithMetadata {
+		return LintErrorsWithMetadata{}
+	}
+	tests := []struct {
+		name    string
+		rule    LintRuleMetadata
+		wantErr bool
+	}{
+		{
+			"custom_rule",
+			LintRuleMetadata{Name: "input-suffix", Description: "input-suffix checks whether input types end with Input", RuleFunction: inputSuffix},
+			false,
+		},
+		{
+			"duplicate_of_stock_rule",
+			LintRuleMetadata{Name: "Type-Desc", RuleFunction: inputSuffix},
+			true,
+		},
+		{
+			"duplicate_of_custom_rule",
+			LintRuleMetadata{Name: "input-suffix", RuleFunction: inputSuffix},
+			true,
+		},
+		{
+			"empty_name",
+			LintRuleMetadata{Name: " ", RuleFunction: inputSuffix},
+			true,
+		},
+		{
+			"name_with_severity_separator",
+			LintRuleMetadata{Name: "input:suffix", RuleFunction: inputSuffix},
+			true,
+		},
+		{
+			"missing_rule_function",
+			LintRuleMetadata{Name: "key-id"},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterRule(tt.rule); (err != nil) != tt.wantErr {
+				t.Errorf("RegisterRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	registered := AllTheRules[len(AllTheRules)-1]
+	if len(AllTheRules) != len(stockRules)+1 || registered.Name != "input-suffix" {
+		t.Fatalf("RegisterRule() rules = %v", AllTheRules)
+	}
+	if registered.Severity != SeverityError {
+		t.Errorf("RegisterRule() default severity = %v, want %v", registered.Severity, SeverityError)
+	}
+}
@@ -0,0 +1,134 @@
+package linter
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName  = "gql"
+	sarifToolURI   = "https://github.com/CrowdStrike/gql"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+// WriteSarifReport writes lint errors of all the files as a SARIF 2.1.0 log, so they can be uploaded as code scanning alerts
+func WriteSarifReport(w io.Writer, lintErrorsByFile map[string][]LintErrorWithMetadata) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           sarifToolName,
+				InformationURI: sarifToolURI,
+				Rules:          make([]sarifRule, 0, len(AllTheRules)),
+			},
+		},
+		Results: make([]sarifResult, 0),
+	}
+	ruleIndex := make(map[LintRule]int, len(AllTheRules))
+	for i, rule := range AllTheRules {
+		ruleIndex[rule.Name] = i
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               string(rule.Name),
+			ShortDescription: sarifMessage{Text: rule.Description},
+		})
+	}
+
+	// sort file names so that the report is the same on every run
+	fileNames := make([]string, 0, len(lintErrorsByFile))
+	for fileName := range lintErrorsByFile {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	for _, fileName := range fileNames {
+		for _, lintErr := range lintErrorsByFile[fileName] {
+			level := "error"
+			if lintErr.Severity == SeverityWarning {
+				level = "warning"
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    string(lintErr.Rule),
+				RuleIndex: ruleIndex[lintErr.Rule],
+				Level:     level,
+				Message:   sarifMessage{Text: lintErr.Err.Error()},
+				Locations: []sarifLocation{
+					{
+						PhysicalLocation: sarifPhysicalLocation{
+							ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(fileName)},
+							Region: sarifRegion{
+								StartLine:   lintErr.Line,
+								StartColumn: lintErr.Column,
+							},
+						},
+					},
+				},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchemaURI,
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,53 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestWriteSarifReport(t *testing.T) {
+	schemaDoc, parseErr := parser.ParseSchema(&ast.Source{
+		Name: "schema.graphql",
+		Input: `
+		type Query {
+			todos: [String!]!
+		}
+		`,
+	})
+	if parseErr != nil {
+		t.Fatalf("WriteSarifReport() invalid input; error = %v", parseErr)
+	}
+	lintErrors := TypesHaveDescription(schemaDoc)
+
+	var buf bytes.Buffer
+	if err := WriteSarifReport(&buf, map[string][]LintErrorWithMetadata{"schemas/schema.graphql": lintErrors}); err != nil {
+		t.Fatalf("WriteSarifReport() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSarifReport() invalid json; error = %v", err)
+	}
+	if log.Version != sarifVersion || len(log.Runs) != 1 {
+		t.Fatalf("WriteSarifReport() unexpected log = %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(AllTheRules) {
+		t.Errorf("WriteSarifReport() rules = %v, want %d rules", run.Tool.Driver.Rules, len(AllTheRules))
+	}
+	if len(run.Results) != 1 {
+		t.Fatalf("WriteSarifReport() results = %v, want 1 result", run.Results)
+	}
+	result := run.Results[0]
+	if result.RuleID != typeDesc || run.Tool.Driver.Rules[result.RuleIndex].ID != typeDesc {
+		t.Errorf("WriteSarifReport() result rule = %s, index %d", result.RuleID, result.RuleIndex)
+	}
+	location := result.Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != "schemas/schema.graphql" || location.Region.StartLine != 2 || location.Region.StartColumn != 8 {
+		t.Errorf("WriteSarifReport() unexpected location = %+v", location)
+	}
+}
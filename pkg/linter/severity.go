@@ -0,0 +1,32 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is the level at which lint errors of a rule are reported
+type Severity string
+
+const (
+	// SeverityError lint errors are reported and fail the lint
+	SeverityError Severity = "error"
+	// SeverityWarning lint errors are reported as warnings which don't fail the lint
+	SeverityWarning Severity = "warn"
+	// SeverityOff turns the rule off
+	SeverityOff Severity = "off"
+)
+
+// ParseSeverity returns the severity for its name, "warning" is accepted as an alias of "warn"
+func ParseSeverity(name string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case string(SeverityError):
+		return SeverityError, nil
+	case string(SeverityWarning), "warning":
+		return SeverityWarning, nil
+	case string(SeverityOff):
+		return SeverityOff, nil
+	default:
+		return "", fmt.Errorf("invalid severity[%s], expected one of: error, warn, off", name)
+	}
+}
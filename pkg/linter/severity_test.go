@@ -0,0 +1,29 @@
+package linter
+
+import "testing"
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Severity
+		wantErr bool
+	}{
+		{"error", SeverityError, false},
+		{"warn", SeverityWarning, false},
+		{"Warning", SeverityWarning, false},
+		{" off ", SeverityOff, false},
+		{"fatal", "", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSeverity(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSeverity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSeverity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
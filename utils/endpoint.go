@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// IntrospectionQuery is the standard introspection query sent to GraphQL endpoints
+const IntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives {
+      name
+      description
+      locations
+      isRepeatable
+      args { ...InputValue }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  specifiedByURL
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// legacyIntrospectionQuery is the introspection query without the fields added in the October 2021 spec, isRepeatable and specifiedByURL,
+// which servers implementing older specs e.g. graphql-js before 16 reject
+var legacyIntrospectionQuery = strings.NewReplacer("      isRepeatable\n", "", "  specifiedByURL\n", "").Replace(IntrospectionQuery)
+
+// endpointTimeout is the time to wait for the endpoint to return the introspection result
+const endpointTimeout = 30 * time.Second
+
+type graphQLResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// IsEndpoint checks whether the schema path is the URL of a GraphQL endpoint instead of a file path
+func IsEndpoint(schemaPath string) bool {
+	return strings.HasPrefix(schemaPath, "http://") || strings.HasPrefix(schemaPath, "https://")
+}
+
+// ReadEndpoint sends the introspection query to the GraphQL endpoint and returns the introspection result keyed by the URL,
+// so it can be parsed with ParseSchema like schema files. Headers are passed as "Name: value" e.g. for authorization.
+// When the endpoint returns errors for the introspection query, it is retried without the fields older servers reject.
+func ReadEndpoint(url string, headers []string) (map[string][]byte, error) {
+	content, graphQLErrors, err := sendIntrospectionQuery(url, headers, IntrospectionQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(graphQLErrors) != 0 {
+		legacyContent, legacyErrors, err := sendIntrospectionQuery(url, headers, legacyIntrospectionQuery)
+		if err != nil || len(legacyErrors) != 0 {
+			return nil, fmt.Errorf("endpoint:%s returned errors for introspection query: %s", url, strings.Join(graphQLErrors, "; "))
+		}
+		content = legacyContent
+	}
+	return map[string][]byte{url: content}, nil
+}
+
+// sendIntrospectionQuery sends the query to the GraphQL endpoint and returns the response along with the messages of its errors
+func sendIntrospectionQuery(url string, headers []string, query string) ([]byte, []string, error) {
+	body, err := json.Marshal(map[string]string{
+		"operationName": "IntrospectionQuery",
+		"query":         query,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid endpoint:%s, error:%v", url, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	for _, header := range headers {
+		name, value, found := strings.Cut(header, ":")
+		if !found || len(strings.TrimSpace(name)) == 0 {
+			return nil, nil, fmt.Errorf("invalid header:%s, expected format is 'Name: value'", header)
+		}
+		request.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	client := &http.Client{Timeout: endpointTimeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to send introspection query to endpoint:%s, error:%v", url, err)
+	}
+	defer response.Body.Close()
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read introspection result from endpoint:%s, error:%v", url, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("endpoint:%s returned status %s", url, response.Status)
+	}
+
+	var graphQLErrors graphQLResponse
+	if err := json.Unmarshal(content, &graphQLErrors); err != nil {
+		return nil, nil, fmt.Errorf("endpoint:%s returned invalid json, error:%v", url, err)
+	}
+	messages := make([]string, 0, len(graphQLErrors.Errors))
+	for _, graphQLError := range graphQLErrors.Errors {
+		messages = append(messages, graphQLError.Message)
+	}
+	return content, messages, nil
+}
@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIsEndpoint(t *testing.T) {
+	tests := []struct {
+		schemaPath string
+		want       bool
+	}{
+		{"http://localhost:4000/graphql", true},
+		{"https://api.example.com/graphql", true},
+		{"schema/*.graphql", false},
+		{"http.graphql", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schemaPath, func(t *testing.T) {
+			if got := IsEndpoint(tt.schemaPath); got != tt.want {
+				t.Errorf("IsEndpoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadEndpoint(t *testing.T) {
+	introspection, err := os.ReadFile("test_schema/library.json")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query string `json:"query"`
+		}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || !strings.Contains(request.Query, "__schema") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Header.Get("Authorization") {
+		case "Bearer token":
+			_, _ = w.Write(introspection)
+		case "Bearer expired":
+			_, _ = w.Write([]byte(`{"errors": [{"message": "token expired"}], "data": null}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		headers []string
+		wantErr bool
+	}{
+		{
+			name:    "introspection result",
+			url:     server.URL,
+			headers: []string{"Authorization: Bearer token", "X-Client-Name: gql"},
+		},
+		{
+			name:    "unauthorized",
+			url:     server.URL,
+			wantErr: true,
+		},
+		{
+			name:    "graphql errors",
+			url:     server.URL,
+			headers: []string{"Authorization: Bearer expired"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid header",
+			url:     server.URL,
+			headers: []string{"Authorization"},
+			wantErr: true,
+		},
+		{
+			name:    "unreachable endpoint",
+			url:     "http://127.0.0.1:0/graphql",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents, err := ReadEndpoint(tt.url, tt.headers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			schema, err := ParseSchema(contents)
+			if err != nil {
+				t.Fatalf("ParseSchema() error = %v", err)
+			}
+			book := schema.Definitions.ForName("Book")
+			if book == nil || book.Position.Src.Name != server.URL {
+				t.Errorf("ReadEndpoint() schema = %+v, want Book type read from %s", book, server.URL)
+			}
+		})
+	}
+}
+
+func TestReadEndpointLegacyServer(t *testing.T) {
+	introspection, err := os.ReadFile("test_schema/library.json")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		queries = append(queries, request.Query)
+		// servers implementing older specs reject the fields they don't know
+		for _, field := range []string{"isRepeatable", "specifiedByURL"} {
+			if strings.Contains(request.Query, field) {
+				_, _ = w.Write([]byte(`{"errors": [{"message": "Cannot query field \"` + field + `\""}]}`))
+				return
+			}
+		}
+		_, _ = w.Write(introspection)
+	}))
+	defer server.Close()
+
+	contents, err := ReadEndpoint(server.URL, nil)
+	if err != nil {
+		t.Fatalf("ReadEndpoint() error = %v", err)
+	}
+	if len(queries) != 2 {
+		t.Errorf("ReadEndpoint() sent %d queries, want the query and its legacy retry", len(queries))
+	}
+	schema, err := ParseSchema(contents)
+	if err != nil {
+		t.Fatalf("ParseSchema() error = %v", err)
+	}
+	if schema.Definitions.ForName("Book") == nil {
+		t.Errorf("ReadEndpoint() schema doesn't have the Book type")
+	}
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ReadFilesFromGitRef read file contents matching the given filepath from a git revision of the repository in the working directory.
+// Relative filepaths are resolved against the working directory like ReadFiles does, so the same glob can be used for both.
+func ReadFilesFromGitRef(ref string, schemaFilePath string) (map[string][]byte, error) {
+	if len(ref) == 0 || strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid git revision:%s", ref)
+	}
+	topLevel, err := runGit("", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find git repository, error:%v", err)
+	}
+	repoDir := strings.TrimSpace(string(topLevel))
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	// git reports the top level with symlinks resolved, the working directory has to match it
+	if resolvedDir, err := filepath.EvalSymlinks(workingDir); err == nil {
+		workingDir = resolvedDir
+	}
+
+	absolutePath := schemaFilePath
+	if !filepath.IsAbs(absolutePath) {
+		absolutePath = filepath.Join(workingDir, schemaFilePath)
+	}
+	repoGlob, err := filepath.Rel(repoDir, absolutePath)
+	if err != nil || strings.HasPrefix(repoGlob, "..") {
+		return nil, fmt.Errorf("filepath:%s is outside of git repository:%s", schemaFilePath, repoDir)
+	}
+	// the glob is matched element by element like Glob does, so '**' matches any number of directories
+	globElements := strings.Split(filepath.ToSlash(repoGlob), "/")
+	for _, element := range globElements {
+		if _, err := path.Match(element, ""); err != nil {
+			return nil, fmt.Errorf("matching files do not exist at path:%s, error:%v", schemaFilePath, err)
+		}
+	}
+
+	// git ls-tree lists the files from the top level when run there, NUL separated since paths can have new lines
+	tree, err := runGit(repoDir, "ls-tree", "-r", "-z", "--name-only", ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files in git revision:%s, error:%v", ref, err)
+	}
+	schemaFileContents := make(map[string][]byte)
+	for _, repoFile := range strings.Split(string(tree), "\x00") {
+		if len(repoFile) == 0 {
+			continue
+		}
+		if !matchElements(globElements, strings.Split(repoFile, "/")) {
+			continue
+		}
+		content, err := runGit(repoDir, "cat-file", "blob", fmt.Sprintf("%s:%s", ref, repoFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file:%s in git revision:%s, error:%v", repoFile, ref, err)
+		}
+		filename := filepath.Join(repoDir, filepath.FromSlash(repoFile))
+		if !filepath.IsAbs(schemaFilePath) {
+			// name the file like it would be named reading it from the working directory
+			if relativeName, err := filepath.Rel(workingDir, filename); err == nil {
+				filename = relativeName
+			}
+		}
+		schemaFileContents[filename] = content
+	}
+	if len(schemaFileContents) == 0 {
+		return nil, fmt.Errorf("matching file does not exist at path:%s in git revision:%s", schemaFilePath, ref)
+	}
+	return schemaFileContents, nil
+}
+
+func runGit(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); len(message) != 0 {
+			return nil, fmt.Errorf("%v: %s", err, message)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
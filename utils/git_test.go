@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=gql", "GIT_AUTHOR_EMAIL=gql@example.com",
+		"GIT_COMMITTER_NAME=gql", "GIT_COMMITTER_EMAIL=gql@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed, error = %v, output = %s", args, err, output)
+	}
+}
+
+func writeSchema(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create directory, error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write file, error = %v", err)
+	}
+}
+
+func TestReadFilesFromGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repoDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to resolve temp dir, error = %v", err)
+	}
+	git(t, repoDir, "init", "-q")
+	writeSchema(t, filepath.Join(repoDir, "schema", "book.graphql"), "type Book { isbn: ID }")
+	writeSchema(t, filepath.Join(repoDir, "schema", "author.graphql"), "type Author { name: String }")
+	writeSchema(t, filepath.Join(repoDir, "schema", "nested", "shelf.graphql"), "type Shelf { id: ID }")
+	writeSchema(t, filepath.Join(repoDir, "schema", "nested", "deep", "row.graphql"), "type Row { id: ID }")
+	git(t, repoDir, "add", "-A")
+	git(t, repoDir, "commit", "-q", "-m", "base")
+	git(t, repoDir, "tag", "base")
+	writeSchema(t, filepath.Join(repoDir, "schema", "book.graphql"), "type Book { isbn: ID, title: String }")
+	writeSchema(t, filepath.Join(repoDir, "schema", "library.graphql"), "type Library { id: ID }")
+	git(t, repoDir, "add", "-A")
+	git(t, repoDir, "commit", "-q", "-m", "head")
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory, error = %v", err)
+	}
+	defer func() {
+		_ = os.Chdir(workingDir)
+	}()
+	if err := os.Chdir(filepath.Join(repoDir, "schema")); err != nil {
+		t.Fatalf("failed to change working directory, error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		ref      string
+		filepath string
+		want     map[string][]byte
+		wantErr  bool
+	}{
+		{
+			name:     "base revision",
+			ref:      "base",
+			filepath: "*.graphql",
+			want: map[string][]byte{
+				"author.graphql": []byte("type Author { name: String }"),
+				"book.graphql":   []byte("type Book { isbn: ID }"),
+			},
+		},
+		{
+			name:     "head revision",
+			ref:      "HEAD",
+			filepath: "b*.graphql",
+			want: map[string][]byte{
+				"book.graphql": []byte("type Book { isbn: ID, title: String }"),
+			},
+		},
+		{
+			name:     "path outside working directory",
+			ref:      "HEAD~1",
+			filepath: "../schema/nested/*.graphql",
+			want: map[string][]byte{
+				filepath.Join("nested", "shelf.graphql"): []byte("type Shelf { id: ID }"),
+			},
+		},
+		{
+			name:     "double star matches any number of directories",
+			ref:      "base",
+			filepath: "../schema/**/*.graphql",
+			want: map[string][]byte{
+				"author.graphql":                               []byte("type Author { name: String }"),
+				"book.graphql":                                 []byte("type Book { isbn: ID }"),
+				filepath.Join("nested", "shelf.graphql"):       []byte("type Shelf { id: ID }"),
+				filepath.Join("nested", "deep", "row.graphql"): []byte("type Row { id: ID }"),
+			},
+		},
+		{
+			name:     "absolute path",
+			ref:      "base",
+			filepath: filepath.Join(repoDir, "schema", "author.graphql"),
+			want: map[string][]byte{
+				filepath.Join(repoDir, "schema", "author.graphql"): []byte("type Author { name: String }"),
+			},
+		},
+		{
+			name:     "no matching file",
+			ref:      "base",
+			filepath: "library.graphql",
+			wantErr:  true,
+		},
+		{
+			name:     "unknown revision",
+			ref:      "does-not-exist",
+			filepath: "*.graphql",
+			wantErr:  true,
+		},
+		{
+			name:     "option as revision",
+			ref:      "--help",
+			filepath: "*.graphql",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadFilesFromGitRef(tt.ref, tt.filepath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadFilesFromGitRef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadFilesFromGitRef() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// doubleStar is the path element matching any number of directories in globs
+const doubleStar = "**"
+
+// Glob returns the names of all files matching the pattern like filepath.Glob, a '**' path element matches any number of directories
+func Glob(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, doubleStar) {
+		return filepath.Glob(pattern)
+	}
+	elements := strings.Split(filepath.ToSlash(filepath.Clean(pattern)), "/")
+	for _, element := range elements {
+		if _, err := path.Match(element, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// walk from the longest leading directory without wildcards
+	static := 0
+	for static < len(elements)-1 && !hasMeta(elements[static]) {
+		static++
+	}
+	root := filepath.FromSlash(strings.Join(elements[:static], "/"))
+	if len(root) == 0 {
+		root = "."
+		if filepath.IsAbs(pattern) {
+			root = string(filepath.Separator)
+		}
+	}
+
+	var matches []string
+	err := filepath.WalkDir(root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if name == root {
+				// like filepath.Glob a missing directory is not an error
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+		if matchElements(elements[static:], strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, name)
+		}
+		return nil
+	})
+	return matches, err
+}
+
+// matchElements matches path elements against pattern elements, where '**' matches zero or more elements
+func matchElements(pattern []string, elements []string) bool {
+	if len(pattern) == 0 {
+		return len(elements) == 0
+	}
+	if pattern[0] == doubleStar {
+		for i := 0; i <= len(elements); i++ {
+			if matchElements(pattern[1:], elements[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(elements) == 0 {
+		return false
+	}
+	if matched, _ := path.Match(pattern[0], elements[0]); !matched {
+		return false
+	}
+	return matchElements(pattern[1:], elements[1:])
+}
+
+func hasMeta(element string) bool {
+	return strings.ContainsAny(element, `*?[\`)
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGlob(t *testing.T) {
+	dir := t.TempDir()
+	writeSchema(t, filepath.Join(dir, "clients", "book.graphql"), "query A { a }")
+	writeSchema(t, filepath.Join(dir, "clients", "web", "author.graphql"), "query B { b }")
+	writeSchema(t, filepath.Join(dir, "clients", "web", "pages", "shelf.graphql"), "query C { c }")
+	writeSchema(t, filepath.Join(dir, "clients", "web", "readme.md"), "# web")
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "double star matches any number of directories",
+			pattern: "clients/**/*.graphql",
+			want:    []string{"clients/book.graphql", "clients/web/author.graphql", "clients/web/pages/shelf.graphql"},
+		},
+		{
+			name:    "double star in the middle of the pattern",
+			pattern: "clients/**/pages/*.graphql",
+			want:    []string{"clients/web/pages/shelf.graphql"},
+		},
+		{
+			name:    "double star at the end of the pattern",
+			pattern: "clients/web/**",
+			want:    []string{"clients/web/author.graphql", "clients/web/pages/shelf.graphql", "clients/web/readme.md"},
+		},
+		{
+			name:    "pattern without double star",
+			pattern: "clients/*/*.graphql",
+			want:    []string{"clients/web/author.graphql"},
+		},
+		{
+			name:    "missing directory",
+			pattern: "missing/**/*.graphql",
+			want:    nil,
+		},
+		{
+			name:    "bad pattern",
+			pattern: "clients/**/[.graphql",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Glob(filepath.Join(dir, filepath.FromSlash(tt.pattern)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Glob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var rel []string
+			for _, name := range got {
+				r, err := filepath.Rel(dir, name)
+				if err != nil {
+					t.Fatalf("failed to get relative path, error = %v", err)
+				}
+				rel = append(rel, filepath.ToSlash(r))
+			}
+			if !reflect.DeepEqual(rel, tt.want) {
+				t.Errorf("Glob() = %v, want %v", rel, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// builtInScalars are not part of SDL schemas, so they are left out of schemas converted from introspection results
+var builtInScalars = map[string]bool{"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true}
+
+// builtInDirectives are not part of SDL schemas, so they are left out of schemas converted from introspection results
+var builtInDirectives = map[string]bool{"skip": true, "include": true, "deprecated": true, "specifiedBy": true}
+
+// defaultDeprecationReason is the reason of @deprecated when none is passed
+const defaultDeprecationReason = "No longer supported"
+
+type introspectionResult struct {
+	Data   *introspectionData   `json:"data"`
+	Schema *introspectionSchema `json:"__schema"`
+}
+
+type introspectionData struct {
+	Schema *introspectionSchema `json:"__schema"`
+}
+
+type introspectionSchema struct {
+	Description      string                   `json:"description"`
+	QueryType        *introspectionTypeRef    `json:"queryType"`
+	MutationType     *introspectionTypeRef    `json:"mutationType"`
+	SubscriptionType *introspectionTypeRef    `json:"subscriptionType"`
+	Types            []introspectionType      `json:"types"`
+	Directives       []introspectionDirective `json:"directives"`
+}
+
+type introspectionType struct {
+	Kind           string                   `json:"kind"`
+	Name           string                   `json:"name"`
+	Description    string                   `json:"description"`
+	SpecifiedByURL *string                  `json:"specifiedByURL"`
+	Fields         []introspectionField     `json:"fields"`
+	InputFields    []introspectionInput     `json:"inputFields"`
+	Interfaces     []introspectionTypeRef   `json:"interfaces"`
+	EnumValues     []introspectionEnumValue `json:"enumValues"`
+	PossibleTypes  []introspectionTypeRef   `json:"possibleTypes"`
+}
+
+type introspectionField struct {
+	Name              string               `json:"name"`
+	Description       string               `json:"description"`
+	Args              []introspectionInput `json:"args"`
+	Type              introspectionTypeRef `json:"type"`
+	IsDeprecated      bool                 `json:"isDeprecated"`
+	DeprecationReason *string              `json:"deprecationReason"`
+}
+
+type introspectionInput struct {
+	Name              string               `json:"name"`
+	Description       string               `json:"description"`
+	Type              introspectionTypeRef `json:"type"`
+	DefaultValue      *string              `json:"defaultValue"`
+	IsDeprecated      bool                 `json:"isDeprecated"`
+	DeprecationReason *string              `json:"deprecationReason"`
+}
+
+type introspectionEnumValue struct {
+	Name              string  `json:"name"`
+	Description       string  `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+type introspectionDirective struct {
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Locations    []string             `json:"locations"`
+	Args         []introspectionInput `json:"args"`
+	IsRepeatable bool                 `json:"isRepeatable"`
+}
+
+type introspectionTypeRef struct {
+	Kind   string                `json:"kind"`
+	Name   string                `json:"name"`
+	OfType *introspectionTypeRef `json:"ofType"`
+}
+
+// IsIntrospectionJSON checks whether the schema file is an introspection result, by its json extension or by its content
+// starting with a curly brace which SDL schemas can't start with
+func IsIntrospectionJSON(filename string, content []byte) bool {
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		return true
+	}
+	trimmed := bytes.TrimLeft(content, " \t\r\n\ufeff")
+	return len(trimmed) != 0 && trimmed[0] == '{'
+}
+
+// ParseIntrospection converts the __schema introspection result, with or without the data envelope, to a schema document.
+// The result has no source positions, so every element gets a synthetic position as if the schema was printed as SDL.
+func ParseIntrospection(filename string, content []byte) (*ast.SchemaDocument, error) {
+	var result introspectionResult
+	if err := json.Unmarshal(content, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse introspection result in file:%s, error:%v", filename, err)
+	}
+	schema := result.Schema
+	if schema == nil && result.Data != nil {
+		schema = result.Data.Schema
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("introspection result in file:%s does not have __schema", filename)
+	}
+
+	c := &introspectionConverter{source: &ast.Source{Name: filename}, line: 1}
+	doc := &ast.SchemaDocument{}
+	if schemaDefinition := c.schemaDefinition(schema); schemaDefinition != nil {
+		doc.Schema = append(doc.Schema, schemaDefinition)
+	}
+	for _, directive := range schema.Directives {
+		if builtInDirectives[directive.Name] {
+			continue
+		}
+		directiveDefinition, err := c.directiveDefinition(directive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert directive @%s in file:%s, error:%v", directive.Name, filename, err)
+		}
+		doc.Directives = append(doc.Directives, directiveDefinition)
+	}
+	for _, typ := range schema.Types {
+		if strings.HasPrefix(typ.Name, "__") || builtInScalars[typ.Name] {
+			continue
+		}
+		definition, err := c.definition(typ)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert type %s in file:%s, error:%v", typ.Name, filename, err)
+		}
+		doc.Definitions = append(doc.Definitions, definition)
+	}
+	return doc, nil
+}
+
+// introspectionConverter converts introspection types to schema definitions, counting lines as if they were printed as SDL
+type introspectionConverter struct {
+	source *ast.Source
+	line   int
+}
+
+func (c *introspectionConverter) position(column int) *ast.Position {
+	return &ast.Position{Line: c.line, Column: column, Src: c.source}
+}
+
+// schemaDefinition returns the schema definition, nil when the root operation types have the default names
+func (c *introspectionConverter) schemaDefinition(schema *introspectionSchema) *ast.SchemaDefinition {
+	operationTypes := []struct {
+		operation   ast.Operation
+		typ         *introspectionTypeRef
+		defaultName string
+	}{
+		{ast.Query, schema.QueryType, "Query"},
+		{ast.Mutation, schema.MutationType, "Mutation"},
+		{ast.Subscription, schema.SubscriptionType, "Subscription"},
+	}
+	isDefault := len(schema.Description) == 0
+	for _, operationType := range operationTypes {
+		if operationType.typ != nil && operationType.typ.Name != operationType.defaultName {
+			isDefault = false
+		}
+	}
+	if isDefault {
+		return nil
+	}
+
+	schemaDefinition := &ast.SchemaDefinition{
+		Description: schema.Description,
+		Position:    c.position(1),
+	}
+	for _, operationType := range operationTypes {
+		if operationType.typ == nil {
+			continue
+		}
+		c.line++
+		schemaDefinition.OperationTypes = append(schemaDefinition.OperationTypes, &ast.OperationTypeDefinition{
+			Operation: operationType.operation,
+			Type:      operationType.typ.Name,
+			Position:  c.position(3),
+		})
+	}
+	c.line += 3
+	return schemaDefinition
+}
+
+func (c *introspectionConverter) directiveDefinition(directive introspectionDirective) (*ast.DirectiveDefinition, error) {
+	directiveDefinition := &ast.DirectiveDefinition{
+		Description:  directive.Description,
+		Name:         directive.Name,
+		IsRepeatable: directive.IsRepeatable,
+		Position:     c.position(12),
+	}
+	for _, location := range directive.Locations {
+		directiveDefinition.Locations = append(directiveDefinition.Locations, ast.DirectiveLocation(location))
+	}
+	for _, arg := range directive.Args {
+		argument, err := c.argumentDefinition(arg)
+		if err != nil {
+			return nil, err
+		}
+		directiveDefinition.Arguments = append(directiveDefinition.Arguments, argument)
+	}
+	c.line += 2
+	return directiveDefinition, nil
+}
+
+func (c *introspectionConverter) definition(typ introspectionType) (*ast.Definition, error) {
+	definition := &ast.Definition{
+		Kind:        ast.DefinitionKind(typ.Kind),
+		Description: typ.Description,
+		Name:        typ.Name,
+		Position:    c.position(len(definitionKeyword(typ.Kind)) + 2),
+	}
+	switch definition.Kind {
+	case ast.Scalar, ast.Object, ast.Interface, ast.Union, ast.Enum, ast.InputObject:
+	default:
+		return nil, fmt.Errorf("unknown kind %s", typ.Kind)
+	}
+	if typ.SpecifiedByURL != nil {
+		definition.Directives = append(definition.Directives, c.directive("specifiedBy", "url", *typ.SpecifiedByURL, 1))
+	}
+	for _, iface := range typ.Interfaces {
+		definition.Interfaces = append(definition.Interfaces, iface.Name)
+	}
+	if definition.Kind == ast.Union {
+		for _, possibleType := range typ.PossibleTypes {
+			definition.Types = append(definition.Types, possibleType.Name)
+		}
+	}
+
+	for _, field := range typ.Fields {
+		c.line++
+		fieldDefinition := &ast.FieldDefinition{
+			Description: field.Description,
+			Name:        field.Name,
+			Position:    c.position(3),
+		}
+		for _, arg := range field.Args {
+			argument, err := c.argumentDefinition(arg)
+			if err != nil {
+				return nil, err
+			}
+			fieldDefinition.Arguments = append(fieldDefinition.Arguments, argument)
+		}
+		fieldDefinition.Type = c.typeReference(field.Type, 3)
+		if field.IsDeprecated {
+			fieldDefinition.Directives = append(fieldDefinition.Directives, c.deprecated(field.DeprecationReason, 3))
+		}
+		definition.Fields = append(definition.Fields, fieldDefinition)
+	}
+	for _, inputField := range typ.InputFields {
+		c.line++
+		argument, err := c.argumentDefinition(inputField)
+		if err != nil {
+			return nil, err
+		}
+		definition.Fields = append(definition.Fields, &ast.FieldDefinition{
+			Description:  argument.Description,
+			Name:         argument.Name,
+			DefaultValue: argument.DefaultValue,
+			Type:         argument.Type,
+			Directives:   argument.Directives,
+			Position:     c.position(3),
+		})
+	}
+	for _, enumValue := range typ.EnumValues {
+		c.line++
+		enumValueDefinition := &ast.EnumValueDefinition{
+			Description: enumValue.Description,
+			Name:        enumValue.Name,
+			Position:    c.position(3),
+		}
+		if enumValue.IsDeprecated {
+			enumValueDefinition.Directives = append(enumValueDefinition.Directives, c.deprecated(enumValue.DeprecationReason, 3))
+		}
+		definition.EnumValues = append(definition.EnumValues, enumValueDefinition)
+	}
+	// closing brace and the empty line between the definitions
+	c.line += 3
+	return definition, nil
+}
+
+func (c *introspectionConverter) argumentDefinition(input introspectionInput) (*ast.ArgumentDefinition, error) {
+	argument := &ast.ArgumentDefinition{
+		Description: input.Description,
+		Name:        input.Name,
+		Type:        c.typeReference(input.Type, 3),
+		Position:    c.position(3),
+	}
+	if input.DefaultValue != nil {
+		value, err := c.value(*input.DefaultValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default value of %s, error:%v", input.Name, err)
+		}
+		argument.DefaultValue = value
+	}
+	if input.IsDeprecated {
+		argument.Directives = append(argument.Directives, c.deprecated(input.DeprecationReason, 3))
+	}
+	return argument, nil
+}
+
+func (c *introspectionConverter) typeReference(ref introspectionTypeRef, column int) *ast.Type {
+	switch ref.Kind {
+	case "NON_NULL":
+		if ref.OfType == nil {
+			return nil
+		}
+		typ := c.typeReference(*ref.OfType, column)
+		if typ != nil {
+			typ.NonNull = true
+		}
+		return typ
+	case "LIST":
+		if ref.OfType == nil {
+			return nil
+		}
+		return &ast.Type{Elem: c.typeReference(*ref.OfType, column+1), Position: c.position(column + 1)}
+	default:
+		return &ast.Type{NamedType: ref.Name, Position: c.position(column)}
+	}
+}
+
+// value parses the default value, which introspection returns as GraphQL literal
+func (c *introspectionConverter) value(literal string) (*ast.Value, error) {
+	doc, err := parser.ParseSchema(&ast.Source{Input: fmt.Sprintf("input I { f: I = %s }", literal)})
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.Definitions) != 1 || len(doc.Definitions[0].Fields) != 1 || doc.Definitions[0].Fields[0].DefaultValue == nil {
+		return nil, fmt.Errorf("invalid literal %s", literal)
+	}
+	value := doc.Definitions[0].Fields[0].DefaultValue
+	c.setValuePosition(value)
+	return value, nil
+}
+
+func (c *introspectionConverter) setValuePosition(value *ast.Value) {
+	value.Position = c.position(3)
+	for _, child := range value.Children {
+		c.setValuePosition(child.Value)
+	}
+}
+
+func (c *introspectionConverter) deprecated(reason *string, column int) *ast.Directive {
+	if reason == nil || *reason == defaultDeprecationReason {
+		return &ast.Directive{Name: "deprecated", Position: c.position(column)}
+	}
+	return c.directive("deprecated", "reason", *reason, column)
+}
+
+func (c *introspectionConverter) directive(name string, argumentName string, argumentValue string, column int) *ast.Directive {
+	return &ast.Directive{
+		Name: name,
+		Arguments: ast.ArgumentList{
+			{
+				Name:     argumentName,
+				Value:    &ast.Value{Kind: ast.StringValue, Raw: argumentValue, Position: c.position(column)},
+				Position: c.position(column),
+			},
+		},
+		Position: c.position(column),
+	}
+}
+
+func definitionKeyword(kind string) string {
+	switch ast.DefinitionKind(kind) {
+	case ast.Object:
+		return "type"
+	case ast.InputObject:
+		return "input"
+	default:
+		return strings.ToLower(kind)
+	}
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"os"
+	"testing"
+
+	"github.com/CrowdStrike/gql/pkg/compare"
+)
+
+func TestIsIntrospectionJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		want     bool
+	}{
+		{"json extension", "schema.JSON", "", true},
+		{"json content", "schema.snapshot", "\n  {\"data\": {}}", true},
+		{"sdl", "schema.graphql", "type Query { books: [String] }", false},
+		{"sdl with description", "schema", "\"\"\"{ not json }\"\"\" type Query { books: [String] }", false},
+		{"empty", "schema.graphql", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsIntrospectionJSON(tt.filename, []byte(tt.content)); got != tt.want {
+				t.Errorf("IsIntrospectionJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIntrospection(t *testing.T) {
+	sdl, err := ReadFiles("test_schema/library.graphql")
+	if err != nil {
+		t.Fatalf("ReadFiles() error = %v", err)
+	}
+	introspection, err := os.ReadFile("test_schema/library.json")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	sdlSchema, err := ParseSchema(sdl)
+	if err != nil {
+		t.Fatalf("ParseSchema() error = %v", err)
+	}
+	introspectionSchema, err := ParseSchema(map[string][]byte{"library.json": introspection})
+	if err != nil {
+		t.Fatalf("ParseSchema() error = %v", err)
+	}
+
+	// introspection result of a schema has to be the same as its SDL, both ways
+	changes := compare.FindChangesInSchemas(sdlSchema, introspectionSchema)
+	changes = append(changes, compare.FindChangesInSchemas(introspectionSchema, sdlSchema)...)
+	for _, change := range changes {
+		t.Errorf("ParseIntrospection() unexpected change: %s", change.GetMessage())
+	}
+
+	if len(introspectionSchema.Definitions) != 8 {
+		t.Errorf("ParseIntrospection() definitions = %d, want 8 without built-in and introspection types", len(introspectionSchema.Definitions))
+	}
+	book := introspectionSchema.Definitions.ForName("Book")
+	if book == nil || book.Position == nil || book.Position.Src.Name != "library.json" {
+		t.Fatalf("ParseIntrospection() Book position = %+v, want synthetic position in library.json", book)
+	}
+	for _, field := range book.Fields {
+		if field.Position == nil || field.Type.Position == nil || field.Position.Line <= book.Position.Line {
+			t.Errorf("ParseIntrospection() field %s position = %+v, want synthetic position after the type", field.Name, field.Position)
+		}
+	}
+}
+
+func TestParseIntrospectionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"invalid json", `{"__schema": `},
+		{"missing schema", `{"data": {"book": null}}`},
+		{"unknown kind", `{"__schema": {"types": [{"kind": "CLASS", "name": "Book"}]}}`},
+		{"invalid default value", `{"__schema": {"types": [{"kind": "INPUT_OBJECT", "name": "Filter", "inputFields": [{"name": "first", "type": {"kind": "SCALAR", "name": "Int"}, "defaultValue": "{"}]}]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseIntrospection("schema.json", []byte(tt.content)); err == nil {
+				t.Errorf("ParseIntrospection() error = nil, want error")
+			}
+		})
+	}
+}
@@ -4,16 +4,24 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 
 	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/parser"
 )
 
-// ParseSchema parse schema files and combine their sources
+// ParseSchema parse schema files and combine their sources, introspection results are converted and combined as well
 func ParseSchema(schemaFileContents map[string][]byte) (*ast.SchemaDocument, error) {
 	var sources []*ast.Source
+	var introspectionSchemas []*ast.SchemaDocument
 	for fn, sf := range schemaFileContents {
+		if IsIntrospectionJSON(fn, sf) {
+			introspectionSchema, err := ParseIntrospection(fn, sf)
+			if err != nil {
+				return nil, err
+			}
+			introspectionSchemas = append(introspectionSchemas, introspectionSchema)
+			continue
+		}
 		s := &ast.Source{
 			Name:  fn,
 			Input: string(sf),
@@ -25,12 +33,15 @@ func ParseSchema(schemaFileContents map[string][]byte) (*ast.SchemaDocument, err
 	if parseErr != nil {
 		return nil, parseErr
 	}
+	for _, introspectionSchema := range introspectionSchemas {
+		schema.Merge(introspectionSchema)
+	}
 	return schema, nil
 }
 
-// ReadFiles read file contents from the give filepath
+// ReadFiles read file contents from the give filepath, a '**' path element of the glob matches any number of directories
 func ReadFiles(schemaFilePath string) (map[string][]byte, error) {
-	schemaFiles, err := filepath.Glob(schemaFilePath)
+	schemaFiles, err := Glob(schemaFilePath)
 	if err != nil {
 		fmt.Printf("error %v", err)
 		return nil, fmt.Errorf("matching files do not exist at path:%s, error:%v", schemaFilePath, err)
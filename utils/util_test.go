@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestReadFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeSchema(t, filepath.Join(dir, "schema", "book.graphql"), "type Book { isbn: ID }")
+	writeSchema(t, filepath.Join(dir, "schema", "nested", "deep", "shelf.graphql"), "type Shelf { id: ID }")
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory, error = %v", err)
+	}
+	defer func() {
+		_ = os.Chdir(workingDir)
+	}()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to change working directory, error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filepath string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "star matches files of one directory",
+			filepath: "schema/*.graphql",
+			want:     []string{"schema/book.graphql"},
+		},
+		{
+			name:     "double star matches any number of directories",
+			filepath: "schema/**/*.graphql",
+			want:     []string{"schema/book.graphql", "schema/nested/deep/shelf.graphql"},
+		},
+		{
+			name:     "no matching file",
+			filepath: "schema/*.graphqls",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			contents, err := ReadFiles(tt.filepath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for filename := range contents {
+				got = append(got, filepath.ToSlash(filename))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}